@@ -0,0 +1,262 @@
+package broker
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// package errors
+var (
+	ErrClosed = errors.New("broker is closed")
+)
+
+// relayBatchSize is the maximum number of events read from the log at once.
+const relayBatchSize = 100
+
+// Log is the source of the events relayed by the broker.
+type Log interface {
+	// Read returns up to limit events with position greater than after.
+	Read(ctx context.Context, after uint64, limit int) ([]*pb.Event, error)
+	// Wait blocks until an event with position greater than after is appended or ctx is done.
+	Wait(ctx context.Context, after uint64) error
+}
+
+// Policy decides what happens to an event when the buffer of a subscriber is full.
+type Policy int
+
+// overflow policies
+const (
+	// Block makes the publisher wait until the subscriber catches up.
+	Block Policy = iota
+	// DropOldest discards the oldest buffered event to make room for the new one.
+	DropOldest
+	// Disconnect closes the channel of the subscriber.
+	Disconnect
+)
+
+// Filter selects the events delivered to a subscriber. Empty lists match all events.
+type Filter struct {
+	// Types are fully qualified payload names, e.g. "publishing.ArticlePublished".
+	Types []string
+	// Categories are article categories. Events which don't carry a category never match.
+	Categories []string
+	// AggregateType is the fully qualified name of the entities, e.g. "publishing.Article".
+	AggregateType string
+	// Aggregates are IDs of the entities. The IDs of the entities of different types overlap,
+	// so they are usually combined with AggregateType.
+	Aggregates []uint32
+}
+
+func (f Filter) match(e *pb.Event) bool {
+	return (len(f.Types) == 0 || contains(f.Types, e.Type)) &&
+		(len(f.Categories) == 0 || contains(f.Categories, Category(e))) &&
+		(f.AggregateType == "" || f.AggregateType == e.AggregateType) &&
+		(len(f.Aggregates) == 0 || containsID(f.Aggregates, e.AggregateId))
+}
+
+// Broker fans out published events to subscribers over Go channels.
+type Broker struct {
+	subs map[*subscription]struct{}
+	// stop is closed when the broker shuts down to release blocked publishers
+	stop   <-chan struct{}
+	closed bool
+	sync.RWMutex
+}
+
+type subscription struct {
+	filter Filter
+	policy Policy
+	ch     chan *pb.Event
+	// done is closed as soon as the subscription is cancelled to release blocked publishers
+	done chan struct{}
+	once sync.Once
+}
+
+// New starts a broker which shuts down and closes all subscriptions when ctx is done.
+func New(ctx context.Context) *Broker {
+	b := &Broker{subs: make(map[*subscription]struct{}), stop: ctx.Done()}
+	go func() {
+		<-ctx.Done()
+		b.Lock()
+		defer b.Unlock()
+		b.closed = true
+		for s := range b.subs {
+			s.cancel()
+			close(s.ch)
+			delete(b.subs, s)
+		}
+	}()
+	return b
+}
+
+// Subscribe returns a channel of events matching f, buffering up to size (at least one) events.
+// The channel is closed when ctx is done, when the broker shuts down or when the
+// subscriber is disconnected by the Disconnect policy. The events are shared
+// between subscribers and must not be modified.
+func (b *Broker) Subscribe(ctx context.Context, f Filter, size int, p Policy) <-chan *pb.Event {
+	if size < 1 {
+		size = 1
+	}
+	s := &subscription{
+		filter: f,
+		policy: p,
+		ch:     make(chan *pb.Event, size),
+		done:   make(chan struct{}),
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	if b.closed {
+		close(s.ch)
+		return s.ch
+	}
+	b.subs[s] = struct{}{}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.unsubscribe(s)
+		case <-s.done:
+		}
+	}()
+	return s.ch
+}
+
+// Publish delivers e to every matching subscriber according to its overflow policy.
+func (b *Broker) Publish(ctx context.Context, e *pb.Event) error {
+	b.RLock()
+	if b.closed {
+		b.RUnlock()
+		return ErrClosed
+	}
+	var overflown []*subscription
+	for s := range b.subs {
+		if !s.filter.match(e) {
+			continue
+		}
+		if !s.deliver(ctx, e, b.stop) {
+			overflown = append(overflown, s)
+		}
+	}
+	b.RUnlock()
+
+	for _, s := range overflown {
+		b.unsubscribe(s)
+	}
+	return ctx.Err()
+}
+
+// Relay publishes the events appended to l with position greater than after until ctx is done or the broker shuts down.
+func (b *Broker) Relay(ctx context.Context, l Log, after uint64) error {
+	for {
+		events, err := l.Read(ctx, after, relayBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := b.Publish(ctx, e); err != nil {
+				return err
+			}
+			after = e.Position
+		}
+		if len(events) > 0 {
+			continue
+		}
+		if err := l.Wait(ctx, after); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *Broker) unsubscribe(s *subscription) {
+	// release the publishers blocked on s before waiting for the lock
+	s.cancel()
+
+	b.Lock()
+	defer b.Unlock()
+	if _, ok := b.subs[s]; ok {
+		close(s.ch)
+		delete(b.subs, s)
+	}
+}
+
+// deliver must be called while holding the read lock of the broker.
+// It returns false when the subscriber has to be disconnected.
+func (s *subscription) deliver(ctx context.Context, e *pb.Event, stop <-chan struct{}) bool {
+	switch s.policy {
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- e:
+				return true
+			default:
+			}
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	case Disconnect:
+		select {
+		case s.ch <- e:
+			return true
+		default:
+			return false
+		}
+	default:
+		select {
+		case s.ch <- e:
+		case <-s.done:
+		case <-stop:
+		case <-ctx.Done():
+		}
+		return true
+	}
+}
+
+func (s *subscription) cancel() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// Category returns the article category carried by the event, if any.
+func Category(e *pb.Event) string {
+	switch p := e.Payload.(type) {
+	case *pb.Event_ArticleCreated:
+		return p.ArticleCreated.GetArticle().GetCategory()
+	case *pb.Event_ArticleUpdated:
+		return p.ArticleUpdated.GetArticle().GetCategory()
+	case *pb.Event_ArticleDrafted:
+		return p.ArticleDrafted.GetArticle().GetCategory()
+	case *pb.Event_ArticlePublished:
+		return p.ArticlePublished.GetArticle().GetCategory()
+	case *pb.Event_ArticleRetracted:
+		return p.ArticleRetracted.GetArticle().GetCategory()
+	case *pb.Event_ArticleScheduled:
+		return p.ArticleScheduled.GetArticle().GetCategory()
+	case *pb.Event_ArticleRecategorised:
+		return p.ArticleRecategorised.Category
+	}
+	return ""
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(list []uint32, id uint32) bool {
+	for _, v := range list {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
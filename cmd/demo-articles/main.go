@@ -4,101 +4,193 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/pavelnikolov/eventsourcing-go/broker"
+	"github.com/pavelnikolov/eventsourcing-go/eventstore"
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/search"
 	"github.com/pavelnikolov/eventsourcing-go/services/articles"
+	"github.com/pavelnikolov/eventsourcing-go/services/authors"
 )
 
 const (
 	port = ":50051"
 )
 
+// store is the data store of the articles and authors servers
+type store interface {
+	articles.Factory
+	articles.EventLog
+	Authors() authors.Factory
+}
+
+var (
+	storage  = flag.String("storage", "memory", "data store of the articles: memory or bolt")
+	data     = flag.String("data", "articles.db", "path to the BoltDB file used by the bolt storage")
+	schedule = flag.Duration("schedule", 10*time.Second, "how often the scheduled articles are checked for publishing")
+)
+
 func main() {
+	flag.Parse()
+
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	s := grpc.NewServer()
 
-	db := &articles.Database{}
-	populateContent(db)
+	var db store
+	switch *storage {
+	case "memory":
+		db = &eventstore.Store{}
+	case "bolt":
+		bs, err := eventstore.OpenBolt(*data)
+		if err != nil {
+			log.Fatalf("failed to open %s: %v", *data, err)
+		}
+		defer bs.Close()
+		db = bs
+	default:
+		log.Fatalf("unknown storage %q", *storage)
+	}
+
+	head, err := db.Head(context.Background())
+	if err != nil {
+		log.Fatalf("failed to read events: %v", err)
+	}
+	if head == 0 {
+		populateContent(db)
+		if head, err = db.Head(context.Background()); err != nil {
+			log.Fatalf("failed to read events: %v", err)
+		}
+	}
+
+	// publish the events appended from now on to the in-process subscribers. The broker is fed
+	// from the event log rather than by the articles server, because the authors server and the
+	// scheduler append events too and nothing appended to the log may be missed by the subscribers.
+	//
+	// The only subscriber here logs the events, it demonstrates the fan-out in the process which
+	// owns the log. The search index reads the log directly instead, because it is rebuilt from the
+	// first event on every start and the broker keeps no history. The other services, e.g. the
+	// GraphQL subscriptions of demo-graph, subscribe to the events over gRPC.
+	b := broker.New(context.Background())
+	go logEvents(b.Subscribe(context.Background(), broker.Filter{}, 100, broker.DropOldest))
+	go func() {
+		if err := b.Relay(context.Background(), db, head); err != nil {
+			log.Fatalf("failed to publish events: %v", err)
+		}
+	}()
+
+	// the search index is kept in memory and rebuilt from the events on every start
+	idx, err := search.NewIndex()
+	if err != nil {
+		log.Fatalf("failed to create search index: %v", err)
+	}
+	go func() {
+		if err := idx.Run(context.Background(), db, db); err != nil {
+			log.Fatalf("failed to index articles: %v", err)
+		}
+	}()
+
+	// the scheduler publishes the scheduled articles when their time comes, under the same policy as the server
+	policy := &articles.DefaultPolicy
+	go articles.NewScheduler(db, time.Now, policy).Run(context.Background(), *schedule)
 
-	pb.RegisterArticlesServer(s, articles.NewServer(db))
+	pb.RegisterArticlesServer(s, articles.NewServer(db, db, idx, time.Now, policy))
+	pb.RegisterAuthorsServer(s, authors.NewServer(db.Authors()))
 	reflection.Register(s)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
 
-func populateContent(db *articles.Database) {
+func logEvents(events <-chan *pb.Event) {
+	for e := range events {
+		log.Printf("published %s (%s %d, version %d)\n", e.Type, e.AggregateType, e.AggregateId, e.AggregateVersion)
+	}
+}
+
+func populateContent(db store) {
+	inAMinute, _ := ptypes.TimestampProto(time.Now().Add(time.Minute))
 
+	authors := []*pb.Author{
+		{Id: 10, Name: "Pavel", Bio: "Editor in chief", Email: "pavel@example.com"},
+		{Id: 11, Name: "Alicia G.", Bio: "Business and politics", Email: "alicia@example.com"},
+		{Id: 12, Name: "Peter Pan", Bio: "Lifestyle", Email: "peter@example.com"},
+		{Id: 13, Name: "John Smith", Bio: "Environment", Email: "john@example.com"},
+	}
+
+	for _, a := range authors {
+		db.Authors().Create(context.Background(), a)
+	}
+
+	// the store allocates the IDs of the articles in the order of creation
 	articles := []*pb.Article{
 		{
-			Id:         1,
-			Title:      "My article title 1",
-			Body:       "some articl text here 1",
-			Category:   "business",
-			AuthorId:   10,
-			AuthorName: "Pavel",
-			Status:     pb.ArticleStatus_PUBLISHED,
-			Created:    ptypes.TimestampNow(),
+			Title:    "My article title 1",
+			Body:     "some articl text here 1",
+			Category: "business",
+			AuthorId: 10,
+			Status:   pb.ArticleStatus_PUBLISHED,
+			Created:  ptypes.TimestampNow(),
+		},
+		{
+			Title:    "My article title 2",
+			Body:     "some articl text here 2",
+			Category: "politics",
+			AuthorId: 11,
+			Status:   pb.ArticleStatus_PUBLISHED,
+			Created:  ptypes.TimestampNow(),
 		},
 		{
-			Id:         2,
-			Title:      "My article title 2",
-			Body:       "some articl text here 2",
-			Category:   "politics",
-			AuthorId:   10,
-			AuthorName: "Someone",
-			Status:     pb.ArticleStatus_PUBLISHED,
-			Created:    ptypes.TimestampNow(),
+			Title:    "My article title 3",
+			Body:     "some articl text here 3",
+			Category: "business",
+			AuthorId: 11,
+			Status:   pb.ArticleStatus_PUBLISHED,
+			Created:  ptypes.TimestampNow(),
 		},
 		{
-			Id:         3,
-			Title:      "My article title 3",
-			Body:       "some articl text here 3",
-			Category:   "business",
-			AuthorId:   10,
-			AuthorName: "Alicia G.",
-			Status:     pb.ArticleStatus_PUBLISHED,
-			Created:    ptypes.TimestampNow(),
+			Title:    "My article title 4",
+			Body:     "some articl text here 4",
+			Category: "lifestyle",
+			AuthorId: 12,
+			Status:   pb.ArticleStatus_DRAFT,
+			Created:  ptypes.TimestampNow(),
 		},
 		{
-			Id:         4,
-			Title:      "My article title 4",
-			Body:       "some articl text here 4",
-			Category:   "lifestyle",
-			AuthorId:   10,
-			AuthorName: "Peter Pan",
-			Status:     pb.ArticleStatus_DRAFT,
-			Created:    ptypes.TimestampNow(),
+			Title:    "My article title 5",
+			Body:     "some articl text here 5",
+			Category: "lifestyle",
+			AuthorId: 12,
+			Status:   pb.ArticleStatus_PUBLISHED,
+			Created:  ptypes.TimestampNow(),
 		},
 		{
-			Id:         5,
-			Title:      "My article title 5",
-			Body:       "some articl text here 5",
-			Category:   "lifestyle",
-			AuthorId:   10,
-			AuthorName: "Peter H.",
-			Status:     pb.ArticleStatus_PUBLISHED,
-			Created:    ptypes.TimestampNow(),
+			Title:    "My article title 6",
+			Body:     "some articl text here 6",
+			Category: "environment",
+			AuthorId: 13,
+			Status:   pb.ArticleStatus_RETRACTED,
+			Created:  ptypes.TimestampNow(),
 		},
 		{
-			Id:         6,
-			Title:      "My article title 6",
-			Body:       "some articl text here 6",
-			Category:   "environment",
-			AuthorId:   10,
-			AuthorName: "John Smith",
-			Status:     pb.ArticleStatus_RETRACTED,
-			Created:    ptypes.TimestampNow(),
+			Title:     "My article title 7",
+			Body:      "some articl text here 7",
+			Category:  "environment",
+			AuthorId:  13,
+			Status:    pb.ArticleStatus_SCHEDULED,
+			Created:   ptypes.TimestampNow(),
+			PublishAt: inAMinute,
 		},
 	}
 
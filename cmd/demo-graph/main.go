@@ -3,8 +3,11 @@ package main
 import (
 	"log"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
+	"github.com/pavelnikolov/eventsourcing-go/broker"
+	"github.com/pavelnikolov/eventsourcing-go/eventstream"
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
 	"github.com/pavelnikolov/eventsourcing-go/services/graph"
 )
@@ -21,5 +24,15 @@ func main() {
 	defer conn.Close()
 	c := pb.NewArticlesClient(conn)
 
-	graph.StartServer(c)
+	// fan out the article events to the GraphQL subscriptions
+	ctx := context.Background()
+	b := broker.New(ctx)
+	go func() {
+		req := &pb.SubscribeEventsRequest{Start: pb.SubscribeEventsRequest_LATEST}
+		if err := eventstream.Consume(ctx, c, req, b.Publish, nil); err != nil {
+			log.Fatalf("failed to consume article events: %v", err)
+		}
+	}()
+
+	graph.StartServer(c, pb.NewAuthorsClient(conn), b)
 }
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/services/redirect"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
+)
+
+const (
+	address = "localhost:50051"
+)
+
+func main() {
+	host := flag.String("host", urls.DefaultHost, "host of the canonical URLs of the articles")
+	flag.Parse()
+
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("could not connect: %v", err)
+	}
+	defer conn.Close()
+	c := pb.NewArticlesClient(conn)
+
+	p := redirect.NewProjection()
+	go func() {
+		if err := p.Run(context.Background(), c); err != nil {
+			log.Fatalf("failed to update the redirects: %v", err)
+		}
+	}()
+
+	redirect.StartServer(p, *host)
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"flag"
 	"log"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
 	"github.com/pavelnikolov/eventsourcing-go/services/rss"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
 )
 
 const (
@@ -14,6 +17,16 @@ const (
 )
 
 func main() {
+	checkpoint := flag.String("checkpoint", "feeds.json", "file to save the state of the feeds in, nothing is saved when empty")
+	host := flag.String("host", urls.DefaultHost, "host of the URLs of the articles")
+	admin := flag.String("admin", rss.DefaultAdminAddress, "address of the admin endpoints, they are disabled when empty")
+	flag.Parse()
+
+	p, err := rss.NewProjection(*checkpoint)
+	if err != nil {
+		log.Fatalf("failed to load the feeds: %v", err)
+	}
+
 	// Set up a connection to the server.
 	conn, err := grpc.Dial(address, grpc.WithInsecure())
 	if err != nil {
@@ -22,5 +35,11 @@ func main() {
 	defer conn.Close()
 	c := pb.NewArticlesClient(conn)
 
-	rss.StartServer(c)
+	go func() {
+		if err := p.Run(context.Background(), c); err != nil {
+			log.Fatalf("failed to update the feeds: %v", err)
+		}
+	}()
+
+	rss.StartServer(p, *host, *admin)
 }
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"flag"
 	"log"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
 	"github.com/pavelnikolov/eventsourcing-go/services/sitemap"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
 )
 
 const (
@@ -14,6 +17,10 @@ const (
 )
 
 func main() {
+	host := flag.String("host", urls.DefaultHost, "host of the URLs of the articles")
+	sitemapsHost := flag.String("sitemaps-host", sitemap.DefaultSitemapsHost, "public host the sitemaps are served from")
+	flag.Parse()
+
 	conn, err := grpc.Dial(address, grpc.WithInsecure())
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
@@ -21,5 +28,12 @@ func main() {
 	defer conn.Close()
 	c := pb.NewArticlesClient(conn)
 
-	sitemap.StartServer(c)
+	p := sitemap.NewProjection(*host, *sitemapsHost)
+	go func() {
+		if err := p.Run(context.Background(), c); err != nil {
+			log.Fatalf("failed to update the sitemap: %v", err)
+		}
+	}()
+
+	sitemap.StartServer(p)
 }
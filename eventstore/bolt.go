@@ -0,0 +1,504 @@
+package eventstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/services/articles"
+	"github.com/pavelnikolov/eventsourcing-go/services/authors"
+)
+
+// buckets
+var (
+	// events maps positions to events
+	eventsBucket = []byte("events")
+	// streams maps article IDs and versions to positions
+	streamsBucket = []byte("streams")
+	// articles maps article IDs to the current state of the articles
+	articlesBucket = []byte("articles")
+	// index contains the keys (status, category, created, article ID)
+	indexBucket = []byte("index")
+	// authorStreams maps author IDs and versions to positions
+	authorStreamsBucket = []byte("author_streams")
+	// authors maps author IDs to the current state of the authors
+	authorsBucket = []byte("authors")
+	// keys maps the idempotency keys to the IDs of the articles created with them
+	keysBucket = []byte("keys")
+)
+
+// BoltStore is an append-only event store persisted in a BoltDB file. Next to the
+// log of events it keeps the current state of every article and an index on
+// (status, category, created) so that Latest does not scan every record.
+type BoltStore struct {
+	db *bolt.DB
+	// appended is closed to wake up the readers waiting for new events
+	appended chan struct{}
+	mu       sync.Mutex
+}
+
+// OpenBolt opens the BoltDB file at path, creating it if it doesn't exist.
+func OpenBolt(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{eventsBucket, streamsBucket, articlesBucket, indexBucket, authorStreamsBucket, authorsBucket, keysBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the BoltDB file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// Get returns an article by ID
+func (s *BoltStore) Get(ctx context.Context, id uint32) (*pb.Article, error) {
+	var a *pb.Article
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		a, err = getArticle(tx, id)
+		return err
+	})
+	return a, err
+}
+
+// Create appends the events of a new article to a new stream.
+// The article gets the next free ID unless it has an ID already.
+func (s *BoltStore) Create(ctx context.Context, a *pb.Article) (*pb.Article, error) {
+	var res *pb.Article
+	key := articles.ChangeFrom(ctx).IdempotencyKey
+	err := s.update(func(tx *bolt.Tx) error {
+		keys := tx.Bucket(keysBucket)
+		if key != "" && keys.Get([]byte(key)) != nil {
+			return articles.ErrIdempotencyKey
+		}
+		if a.Id == 0 {
+			a = clone(a)
+			a.Id = 1
+			// the articles are keyed by ID in big-endian order, so the last one has the highest ID
+			if k, _ := tx.Bucket(articlesBucket).Cursor().Last(); k != nil {
+				a.Id = binary.BigEndian.Uint32(k) + 1
+			}
+		}
+		if _, err := getArticle(tx, a.Id); err != articles.ErrArticleNotFound {
+			if err == nil {
+				return articles.ErrArticleExists
+			}
+			return err
+		}
+
+		author, err := getAuthor(tx, a.AuthorId)
+		if err != nil && err != authors.ErrAuthorNotFound {
+			return err
+		}
+		events := newEvents(ctx, a.Id, 0, created(withAuthor(a, author))...)
+		if err := appendEvents(tx, events); err != nil {
+			return err
+		}
+		if key != "" {
+			if err := keys.Put([]byte(key), u32(a.Id)); err != nil {
+				return err
+			}
+		}
+		res = fold(events)
+		return putArticle(tx, nil, res)
+	})
+	return res, err
+}
+
+// Update appends the changes of an existing article to its stream
+// unless the article has been modified since the expected version.
+func (s *BoltStore) Update(ctx context.Context, a *pb.Article, version uint32) (*pb.Article, error) {
+	var res *pb.Article
+	err := s.update(func(tx *bolt.Tx) error {
+		cur, err := getArticle(tx, a.Id)
+		if err != nil {
+			return err
+		}
+		if cur.Version != version {
+			return articles.ErrVersionConflict
+		}
+
+		author, err := getAuthor(tx, a.AuthorId)
+		if err != nil && err != authors.ErrAuthorNotFound {
+			return err
+		}
+		events := newEvents(ctx, a.Id, cur.Version, changes(cur, withAuthor(a, author))...)
+		if err := appendEvents(tx, events); err != nil {
+			return err
+		}
+		res = clone(cur)
+		for _, e := range events {
+			res = apply(res, e)
+		}
+		return putArticle(tx, cur, res)
+	})
+	return res, err
+}
+
+// CreatedWith returns the ID of the article created with the idempotency key.
+func (s *BoltStore) CreatedWith(ctx context.Context, key string) (uint32, error) {
+	var id uint32
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(keysBucket).Get([]byte(key))
+		if v == nil {
+			return articles.ErrArticleNotFound
+		}
+		id = binary.BigEndian.Uint32(v)
+		return nil
+	})
+	return id, err
+}
+
+// Latest returns the most recently created articles matching the query.
+// The articles are indexed by status and category only, the other filters scan the index.
+func (s *BoltStore) Latest(ctx context.Context, q articles.Query) ([]*pb.Article, error) {
+	var res []*pb.Article
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := indexPrefix(q.Status, q.Category)
+		c := tx.Bucket(indexBucket).Cursor()
+
+		// walk backwards from the end of the prefix, or from the article
+		// the page starts after, to get the newest articles first
+		start := append(prefix, bytes.Repeat([]byte{0xff}, 12)...)
+		if q.After != 0 {
+			created := tx.Bucket(streamsBucket).Get(append(u32(q.After), u32(1)...))
+			if created == nil {
+				return articles.ErrArticleNotFound
+			}
+			start = append(append(prefix, created...), u32(q.After)...)
+		}
+		k, _ := c.Seek(start)
+		if k == nil {
+			k, _ = c.Last()
+		} else {
+			k, _ = c.Prev()
+		}
+		for ; k != nil && bytes.HasPrefix(k, prefix) && uint32(len(res)) < q.Count; k, _ = c.Prev() {
+			a, err := getArticle(tx, binary.BigEndian.Uint32(k[len(k)-4:]))
+			if err != nil {
+				return err
+			}
+			if match(a, q) {
+				res = append(res, a)
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Events returns the stream of events of an article.
+func (s *BoltStore) Events(ctx context.Context, id uint32) ([]*pb.Event, error) {
+	var res []*pb.Event
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := u32(id)
+		events := tx.Bucket(eventsBucket)
+		c := tx.Bucket(streamsBucket).Cursor()
+		for k, pos := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, pos = c.Next() {
+			e, err := unmarshalEvent(events.Get(pos))
+			if err != nil {
+				return err
+			}
+			res = append(res, e)
+		}
+		if len(res) == 0 {
+			return articles.ErrArticleNotFound
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Revisions returns the revisions of an article, oldest first.
+func (s *BoltStore) Revisions(ctx context.Context, id uint32) ([]*pb.ArticleRevision, error) {
+	events, err := s.Events(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return revisions(events), nil
+}
+
+// Read returns up to limit events with position greater than after.
+func (s *BoltStore) Read(ctx context.Context, after uint64, limit int) ([]*pb.Event, error) {
+	var res []*pb.Event
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(eventsBucket).Cursor()
+		for k, v := c.Seek(u64(after + 1)); k != nil && len(res) < limit; k, v = c.Next() {
+			e, err := unmarshalEvent(v)
+			if err != nil {
+				return err
+			}
+			res = append(res, e)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Head returns the position of the last event in the log.
+func (s *BoltStore) Head(ctx context.Context) (uint64, error) {
+	var head uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		head = tx.Bucket(eventsBucket).Sequence()
+		return nil
+	})
+	return head, err
+}
+
+// Wait blocks until an event with position greater than after is appended or ctx is done.
+func (s *BoltStore) Wait(ctx context.Context, after uint64) error {
+	s.mu.Lock()
+	head, err := s.Head(ctx)
+	if err != nil || after < head {
+		s.mu.Unlock()
+		return err
+	}
+	if s.appended == nil {
+		s.appended = make(chan struct{})
+	}
+	appended := s.appended
+	s.mu.Unlock()
+
+	select {
+	case <-appended:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Authors returns the authors.Factory backed by the store.
+func (s *BoltStore) Authors() authors.Factory {
+	return boltAuthorStore{s}
+}
+
+// update runs fn in a read-write transaction and wakes up the waiting readers once it is committed.
+func (s *BoltStore) update(fn func(tx *bolt.Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.db.Update(fn); err != nil {
+		return err
+	}
+	if s.appended != nil {
+		close(s.appended)
+		s.appended = nil
+	}
+	return nil
+}
+
+func appendEvents(tx *bolt.Tx, events []*pb.Event) error {
+	eb := tx.Bucket(eventsBucket)
+	for _, e := range events {
+		sb := tx.Bucket(streamsBucket)
+		if e.AggregateType == authorType {
+			sb = tx.Bucket(authorStreamsBucket)
+		}
+		pos, err := eb.NextSequence()
+		if err != nil {
+			return err
+		}
+		e.Position = pos
+		data, err := proto.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if err := eb.Put(u64(pos), data); err != nil {
+			return err
+		}
+		if err := sb.Put(append(u32(e.AggregateId), u32(e.AggregateVersion)...), u64(pos)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getArticle returns the article showing the current name of its author.
+func getArticle(tx *bolt.Tx, id uint32) (*pb.Article, error) {
+	v := tx.Bucket(articlesBucket).Get(u32(id))
+	if v == nil {
+		return nil, articles.ErrArticleNotFound
+	}
+	a := &pb.Article{}
+	if err := proto.Unmarshal(v, a); err != nil {
+		return nil, err
+	}
+	author, err := getAuthor(tx, a.AuthorId)
+	if err == nil {
+		a.AuthorName = author.Name
+	} else if err != authors.ErrAuthorNotFound {
+		return nil, err
+	}
+	return a, nil
+}
+
+func getAuthor(tx *bolt.Tx, id uint32) (*pb.Author, error) {
+	v := tx.Bucket(authorsBucket).Get(u32(id))
+	if v == nil {
+		return nil, authors.ErrAuthorNotFound
+	}
+	a := &pb.Author{}
+	if err := proto.Unmarshal(v, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func putAuthor(tx *bolt.Tx, a *pb.Author) error {
+	data, err := proto.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(authorsBucket).Put(u32(a.Id), data)
+}
+
+// putArticle stores the new state of an article and moves its index entries.
+func putArticle(tx *bolt.Tx, old, a *pb.Article) error {
+	data, err := proto.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(articlesBucket).Put(u32(a.Id), data); err != nil {
+		return err
+	}
+
+	// the position of the first event of the stream orders the articles by creation
+	created := tx.Bucket(streamsBucket).Get(append(u32(a.Id), u32(1)...))
+	index := tx.Bucket(indexBucket)
+	if old != nil {
+		for _, k := range indexKeys(old, created) {
+			if err := index.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	for _, k := range indexKeys(a, created) {
+		if err := index.Put(k, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexKeys returns the index entries of an article. Besides its own status and
+// category, the article is indexed under the "any status" and "any category"
+// wildcards too, so every Latest query is a single prefix scan.
+func indexKeys(a *pb.Article, created []byte) [][]byte {
+	var res [][]byte
+	for _, status := range []pb.ArticleStatus{a.Status, pb.ArticleStatus_UNKNOWN} {
+		for _, category := range []string{a.Category, ""} {
+			k := append(indexPrefix(status, category), created...)
+			res = append(res, append(k, u32(a.Id)...))
+		}
+	}
+	return res
+}
+
+func indexPrefix(status pb.ArticleStatus, category string) []byte {
+	k := append(u32(uint32(status)), category...)
+	return append(k, 0)
+}
+
+func unmarshalEvent(data []byte) (*pb.Event, error) {
+	e := &pb.Event{}
+	if err := proto.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func u32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+func u64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+// boltAuthorStore implements authors.Factory on top of the authors in BoltStore.
+type boltAuthorStore struct {
+	s *BoltStore
+}
+
+// Get returns an author by ID
+func (as boltAuthorStore) Get(ctx context.Context, id uint32) (*pb.Author, error) {
+	var a *pb.Author
+	err := as.s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		a, err = getAuthor(tx, id)
+		return err
+	})
+	return a, err
+}
+
+// Create appends the events of a new author to a new stream
+func (as boltAuthorStore) Create(ctx context.Context, a *pb.Author) (*pb.Author, error) {
+	var res *pb.Author
+	err := as.s.update(func(tx *bolt.Tx) error {
+		if _, err := getAuthor(tx, a.Id); err != authors.ErrAuthorNotFound {
+			if err == nil {
+				return authors.ErrAuthorExists
+			}
+			return err
+		}
+
+		events := newEvents(ctx, a.Id, 0, authorCreated(a)...)
+		if err := appendEvents(tx, events); err != nil {
+			return err
+		}
+		res = foldAuthor(events)
+		return putAuthor(tx, res)
+	})
+	return res, err
+}
+
+// Update appends the changes of an existing author to its stream
+// unless the author has been modified since the expected version.
+func (as boltAuthorStore) Update(ctx context.Context, a *pb.Author, version uint32) (*pb.Author, error) {
+	var res *pb.Author
+	err := as.s.update(func(tx *bolt.Tx) error {
+		cur, err := getAuthor(tx, a.Id)
+		if err != nil {
+			return err
+		}
+		if cur.Version != version {
+			return authors.ErrVersionConflict
+		}
+
+		events := newEvents(ctx, a.Id, cur.Version, authorChanges(cur, a)...)
+		if err := appendEvents(tx, events); err != nil {
+			return err
+		}
+		res = cloneAuthor(cur)
+		for _, e := range events {
+			res = applyAuthor(res, e)
+		}
+		return putAuthor(tx, res)
+	})
+	return res, err
+}
@@ -0,0 +1,295 @@
+package eventstore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/services/articles"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
+)
+
+// newEvents wraps the payloads of a single command into event envelopes.
+// The events share a correlation ID and each of them increments the version of the aggregate.
+// The editor, the cause of the change and the idempotency key of the request are taken from ctx.
+func newEvents(ctx context.Context, id, version uint32, payloads ...proto.Message) []*pb.Event {
+	correlationID := newID()
+	change := articles.ChangeFrom(ctx)
+	var res []*pb.Event
+	for _, p := range payloads {
+		version++
+		e := &pb.Event{
+			Id:               newID(),
+			AggregateId:      id,
+			AggregateVersion: version,
+			Type:             proto.MessageName(p),
+			OccurredAt:       ptypes.TimestampNow(),
+			CausationId:      change.CausationID,
+			CorrelationId:    correlationID,
+			Editor:           change.Editor,
+			Reason:           change.Reason,
+			IdempotencyKey:   change.IdempotencyKey,
+		}
+		setPayload(e, p)
+		if a := snapshot(e); a != nil {
+			a.Version = version
+			if _, ok := p.(*pb.ArticlePublished); ok {
+				a.Published = e.OccurredAt
+			}
+		}
+		if a := authorSnapshot(e); a != nil {
+			a.Version = version
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+// aggregate types
+var (
+	articleType = proto.MessageName(&pb.Article{})
+	authorType  = proto.MessageName(&pb.Author{})
+)
+
+func setPayload(e *pb.Event, p proto.Message) {
+	e.AggregateType = articleType
+	switch p := p.(type) {
+	case *pb.ArticleCreated:
+		e.Payload = &pb.Event_ArticleCreated{ArticleCreated: p}
+	case *pb.ArticleUpdated:
+		e.Payload = &pb.Event_ArticleUpdated{ArticleUpdated: p}
+	case *pb.ArticleDrafted:
+		e.Payload = &pb.Event_ArticleDrafted{ArticleDrafted: p}
+	case *pb.ArticlePublished:
+		e.Payload = &pb.Event_ArticlePublished{ArticlePublished: p}
+	case *pb.ArticleRetracted:
+		e.Payload = &pb.Event_ArticleRetracted{ArticleRetracted: p}
+	case *pb.ArticleScheduled:
+		e.Payload = &pb.Event_ArticleScheduled{ArticleScheduled: p}
+	case *pb.ArticleRetitled:
+		e.Payload = &pb.Event_ArticleRetitled{ArticleRetitled: p}
+	case *pb.ArticleRecategorised:
+		e.Payload = &pb.Event_ArticleRecategorised{ArticleRecategorised: p}
+	case *pb.AuthorCreated:
+		e.AggregateType = authorType
+		e.Payload = &pb.Event_AuthorCreated{AuthorCreated: p}
+	case *pb.AuthorUpdated:
+		e.AggregateType = authorType
+		e.Payload = &pb.Event_AuthorUpdated{AuthorUpdated: p}
+	case *pb.AuthorRenamed:
+		e.AggregateType = authorType
+		e.Payload = &pb.Event_AuthorRenamed{AuthorRenamed: p}
+	default:
+		panic(fmt.Sprintf("unsupported event payload %T", p))
+	}
+}
+
+// snapshot returns the state of the article carried by the event, if any.
+func snapshot(e *pb.Event) *pb.Article {
+	switch p := e.Payload.(type) {
+	case *pb.Event_ArticleCreated:
+		return p.ArticleCreated.Article
+	case *pb.Event_ArticleUpdated:
+		return p.ArticleUpdated.Article
+	case *pb.Event_ArticleDrafted:
+		return p.ArticleDrafted.Article
+	case *pb.Event_ArticlePublished:
+		return p.ArticlePublished.Article
+	case *pb.Event_ArticleRetracted:
+		return p.ArticleRetracted.Article
+	case *pb.Event_ArticleScheduled:
+		return p.ArticleScheduled.Article
+	}
+	return nil
+}
+
+// authorSnapshot returns the state of the author carried by the event, if any.
+func authorSnapshot(e *pb.Event) *pb.Author {
+	switch p := e.Payload.(type) {
+	case *pb.Event_AuthorCreated:
+		return p.AuthorCreated.Author
+	case *pb.Event_AuthorUpdated:
+		return p.AuthorUpdated.Author
+	}
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// created returns the events recorded when an article is created.
+func created(a *pb.Article) []proto.Message {
+	// the publishing time is set by the store only
+	a = clone(a)
+	a.Published = nil
+	if a.Slug == "" {
+		a.Slug = urls.Slug(a.Title)
+	}
+	res := []proto.Message{&pb.ArticleCreated{Article: clone(a)}}
+	if t := transition(a); t != nil {
+		res = append(res, t)
+	}
+	return res
+}
+
+// changes compares the current state of an article with the desired one and
+// returns the events which are needed to get from the former to the latter.
+func changes(cur, a *pb.Article) []proto.Message {
+	a = clone(a)
+	a.Published = cur.Published
+	switch {
+	case a.Slug != "":
+	case cur.Slug != "":
+		a.Slug = cur.Slug
+	default:
+		// the article was created before the slugs were stored, its URL is kept from now on
+		a.Slug = urls.Slug(cur.Title)
+	}
+
+	var res []proto.Message
+	if cur.Title != a.Title {
+		res = append(res, &pb.ArticleRetitled{Title: a.Title, PreviousTitle: cur.Title})
+	}
+	if cur.Category != a.Category {
+		res = append(res, &pb.ArticleRecategorised{Category: a.Category, PreviousCategory: cur.Category})
+	}
+
+	// any other difference is recorded as a content update
+	c := clone(cur)
+	c.Title, c.Category, c.Status, c.Version = a.Title, a.Category, a.Status, a.Version
+	if !proto.Equal(c, a) {
+		res = append(res, &pb.ArticleUpdated{Article: clone(a)})
+	}
+
+	if t := transition(a); t != nil && cur.Status != a.Status {
+		res = append(res, t)
+	}
+	return res
+}
+
+// transition returns the event recorded when an article moves to its current status.
+func transition(a *pb.Article) proto.Message {
+	switch a.Status {
+	case pb.ArticleStatus_DRAFT:
+		return &pb.ArticleDrafted{Article: clone(a)}
+	case pb.ArticleStatus_PUBLISHED:
+		return &pb.ArticlePublished{Article: clone(a)}
+	case pb.ArticleStatus_RETRACTED:
+		return &pb.ArticleRetracted{Article: clone(a)}
+	case pb.ArticleStatus_SCHEDULED:
+		return &pb.ArticleScheduled{Article: clone(a)}
+	}
+	return nil
+}
+
+// fold rebuilds the current state of an article from its events.
+func fold(events []*pb.Event) *pb.Article {
+	var a *pb.Article
+	for _, e := range events {
+		a = apply(a, e)
+	}
+	return a
+}
+
+func apply(a *pb.Article, e *pb.Event) *pb.Article {
+	switch p := e.Payload.(type) {
+	case *pb.Event_ArticleCreated:
+		a = clone(p.ArticleCreated.Article)
+	case *pb.Event_ArticleUpdated:
+		a = clone(p.ArticleUpdated.Article)
+	case *pb.Event_ArticleDrafted:
+		a.Status = pb.ArticleStatus_DRAFT
+	case *pb.Event_ArticlePublished:
+		a.Status = pb.ArticleStatus_PUBLISHED
+		a.Published = e.OccurredAt
+	case *pb.Event_ArticleRetracted:
+		a.Status = pb.ArticleStatus_RETRACTED
+	case *pb.Event_ArticleScheduled:
+		a.Status = pb.ArticleStatus_SCHEDULED
+	case *pb.Event_ArticleRetitled:
+		a.Title = p.ArticleRetitled.Title
+	case *pb.Event_ArticleRecategorised:
+		a.Category = p.ArticleRecategorised.Category
+	}
+	a.Version = e.AggregateVersion
+	return a
+}
+
+func clone(a *pb.Article) *pb.Article {
+	return proto.Clone(a).(*pb.Article)
+}
+
+// withAuthor returns a copy of the article showing the current name of its author.
+func withAuthor(a *pb.Article, author *pb.Author) *pb.Article {
+	a = clone(a)
+	if author != nil {
+		a.AuthorName = author.Name
+	}
+	return a
+}
+
+// authorCreated returns the events recorded when an author is created.
+func authorCreated(a *pb.Author) []proto.Message {
+	return []proto.Message{&pb.AuthorCreated{Author: cloneAuthor(a)}}
+}
+
+// authorChanges compares the current state of an author with the desired one and
+// returns the events which are needed to get from the former to the latter.
+func authorChanges(cur, a *pb.Author) []proto.Message {
+	var res []proto.Message
+	if cur.Name != a.Name {
+		res = append(res, &pb.AuthorRenamed{Name: a.Name, PreviousName: cur.Name})
+	}
+
+	// any other difference is recorded as a profile update
+	c := cloneAuthor(cur)
+	c.Name, c.Version = a.Name, a.Version
+	if !proto.Equal(c, a) {
+		res = append(res, &pb.AuthorUpdated{Author: cloneAuthor(a)})
+	}
+	return res
+}
+
+// foldAuthor rebuilds the current state of an author from its events.
+func foldAuthor(events []*pb.Event) *pb.Author {
+	var a *pb.Author
+	for _, e := range events {
+		a = applyAuthor(a, e)
+	}
+	return a
+}
+
+func applyAuthor(a *pb.Author, e *pb.Event) *pb.Author {
+	switch p := e.Payload.(type) {
+	case *pb.Event_AuthorCreated:
+		a = cloneAuthor(p.AuthorCreated.Author)
+	case *pb.Event_AuthorUpdated:
+		a = cloneAuthor(p.AuthorUpdated.Author)
+	case *pb.Event_AuthorRenamed:
+		a.Name = p.AuthorRenamed.Name
+	}
+	a.Version = e.AggregateVersion
+	return a
+}
+
+func cloneAuthor(a *pb.Author) *pb.Author {
+	return proto.Clone(a).(*pb.Author)
+}
+
+// match reports whether the article matches the filters of the query.
+func match(a *pb.Article, q articles.Query) bool {
+	return (q.Category == "" || a.Category == q.Category) &&
+		(q.AuthorID == 0 || a.AuthorId == q.AuthorID) &&
+		(q.Status == pb.ArticleStatus_UNKNOWN || a.Status == q.Status) &&
+		(q.Now.IsZero() || !articles.Embargoed(a, q.Now))
+}
@@ -0,0 +1,90 @@
+package eventstore
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// revisions folds the events of an article into its revisions, oldest first.
+// The events recorded for a single command, i.e. sharing a correlation ID, make up one revision.
+func revisions(events []*pb.Event) []*pb.ArticleRevision {
+	var res []*pb.ArticleRevision
+	var prev, a *pb.Article
+	// versions maps the IDs of the last events of the revisions to their versions
+	versions := make(map[string]uint32)
+	for i, e := range events {
+		a = apply(a, e)
+		if i+1 < len(events) && events[i+1].CorrelationId == e.CorrelationId {
+			continue
+		}
+
+		rev := &pb.ArticleRevision{
+			Id:              e.Id,
+			Version:         e.AggregateVersion,
+			Article:         clone(a),
+			Editor:          e.Editor,
+			Reason:          e.Reason,
+			Created:         e.OccurredAt,
+			Changes:         diff(prev, a),
+			RevertedVersion: versions[e.CausationId],
+		}
+		versions[e.Id] = e.AggregateVersion
+		res = append(res, rev)
+		prev = rev.Article
+	}
+	return res
+}
+
+// diff returns the changes of the fields of an article the editors care about.
+// All of the fields are changed by the first revision, when prev is nil.
+func diff(prev, a *pb.Article) []*pb.FieldChange {
+	if prev == nil {
+		prev = &pb.Article{}
+	}
+	var res []*pb.FieldChange
+	add := func(field, from, to string) {
+		if from != to {
+			res = append(res, &pb.FieldChange{Field: field, PreviousValue: from, Value: to})
+		}
+	}
+	add("title", prev.Title, a.Title)
+	add("slug", prev.Slug, a.Slug)
+	add("body", prev.Body, a.Body)
+	add("category", prev.Category, a.Category)
+	add("author_id", authorID(prev), authorID(a))
+	add("status", statusName(prev), statusName(a))
+	add("publish_at", timeValue(prev.PublishAt), timeValue(a.PublishAt))
+	add("embargo_until", timeValue(prev.EmbargoUntil), timeValue(a.EmbargoUntil))
+	return res
+}
+
+func authorID(a *pb.Article) string {
+	if a.AuthorId == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(a.AuthorId), 10)
+}
+
+// timeValue returns the time in RFC 3339, it is empty if the time is not set.
+func timeValue(ts *tspb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func statusName(a *pb.Article) string {
+	if a.Status == pb.ArticleStatus_UNKNOWN {
+		return ""
+	}
+	return a.Status.String()
+}
@@ -0,0 +1,294 @@
+package eventstore
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/services/articles"
+	"github.com/pavelnikolov/eventsourcing-go/services/authors"
+)
+
+// Store is an in-memory append-only event store. It implements articles.Factory
+// by folding the events of every article into its current state and
+// authors.Factory through Authors.
+type Store struct {
+	log           []*pb.Event
+	streams       map[uint32][]*pb.Event
+	authorStreams map[uint32][]*pb.Event
+	// order keeps the article IDs in the order of creation
+	order []uint32
+	// lastID is the highest ID of the articles
+	lastID uint32
+	// keys maps the idempotency keys to the IDs of the articles created with them
+	keys map[string]uint32
+	// appended is closed to wake up the readers waiting for new events
+	appended chan struct{}
+	sync.RWMutex
+}
+
+// Get returns an article by ID
+func (s *Store) Get(ctx context.Context, id uint32) (*pb.Article, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	stream, ok := s.streams[id]
+	if !ok {
+		return nil, articles.ErrArticleNotFound
+	}
+	return s.article(stream), nil
+}
+
+// Create appends the events of a new article to a new stream.
+// The article gets the next free ID unless it has an ID already.
+func (s *Store) Create(ctx context.Context, a *pb.Article) (*pb.Article, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if key := articles.ChangeFrom(ctx).IdempotencyKey; key != "" {
+		if _, ok := s.keys[key]; ok {
+			return nil, articles.ErrIdempotencyKey
+		}
+	}
+	a = withAuthor(a, s.author(a.AuthorId))
+	if a.Id == 0 {
+		a.Id = s.lastID + 1
+	}
+	if _, ok := s.streams[a.Id]; ok {
+		return nil, articles.ErrArticleExists
+	}
+	s.append(newEvents(ctx, a.Id, 0, created(a)...))
+	s.order = append(s.order, a.Id)
+	return s.article(s.streams[a.Id]), nil
+}
+
+// Update appends the changes of an existing article to its stream
+// unless the article has been modified since the expected version.
+func (s *Store) Update(ctx context.Context, a *pb.Article, version uint32) (*pb.Article, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	stream, ok := s.streams[a.Id]
+	if !ok {
+		return nil, articles.ErrArticleNotFound
+	}
+	cur := s.article(stream)
+	if cur.Version != version {
+		return nil, articles.ErrVersionConflict
+	}
+	a = withAuthor(a, s.author(a.AuthorId))
+	s.append(newEvents(ctx, a.Id, cur.Version, changes(cur, a)...))
+	return s.article(s.streams[a.Id]), nil
+}
+
+// CreatedWith returns the ID of the article created with the idempotency key.
+func (s *Store) CreatedWith(ctx context.Context, key string) (uint32, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	id, ok := s.keys[key]
+	if !ok {
+		return 0, articles.ErrArticleNotFound
+	}
+	return id, nil
+}
+
+// Latest returns the most recently created articles matching the query.
+func (s *Store) Latest(ctx context.Context, q articles.Query) ([]*pb.Article, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	start := len(s.order) - 1
+	if q.After != 0 {
+		for start >= 0 && s.order[start] != q.After {
+			start--
+		}
+		if start < 0 {
+			return nil, articles.ErrArticleNotFound
+		}
+		start--
+	}
+
+	var res []*pb.Article
+	for i := start; i >= 0 && uint32(len(res)) < q.Count; i-- {
+		a := s.article(s.streams[s.order[i]])
+		if match(a, q) {
+			res = append(res, a)
+		}
+	}
+
+	return res, nil
+}
+
+// Events returns a copy of the stream of events of an article.
+func (s *Store) Events(ctx context.Context, id uint32) ([]*pb.Event, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	stream, ok := s.streams[id]
+	if !ok {
+		return nil, articles.ErrArticleNotFound
+	}
+	res := make([]*pb.Event, len(stream))
+	for i, e := range stream {
+		res[i] = proto.Clone(e).(*pb.Event)
+	}
+	return res, nil
+}
+
+// Revisions returns the revisions of an article, oldest first.
+func (s *Store) Revisions(ctx context.Context, id uint32) ([]*pb.ArticleRevision, error) {
+	events, err := s.Events(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return revisions(events), nil
+}
+
+// Read returns up to limit events with position greater than after.
+func (s *Store) Read(ctx context.Context, after uint64, limit int) ([]*pb.Event, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if after >= uint64(len(s.log)) {
+		return nil, nil
+	}
+	events := s.log[after:]
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	res := make([]*pb.Event, len(events))
+	for i, e := range events {
+		res[i] = proto.Clone(e).(*pb.Event)
+	}
+	return res, nil
+}
+
+// Head returns the position of the last event in the log.
+func (s *Store) Head(ctx context.Context) (uint64, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return uint64(len(s.log)), nil
+}
+
+// Wait blocks until an event with position greater than after is appended or ctx is done.
+func (s *Store) Wait(ctx context.Context, after uint64) error {
+	s.Lock()
+	if after < uint64(len(s.log)) {
+		s.Unlock()
+		return nil
+	}
+	if s.appended == nil {
+		s.appended = make(chan struct{})
+	}
+	appended := s.appended
+	s.Unlock()
+
+	select {
+	case <-appended:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Authors returns the authors.Factory backed by the store.
+func (s *Store) Authors() authors.Factory {
+	return authorStore{s}
+}
+
+// article must be called while holding the lock. It folds the stream of an article
+// and shows the current name of its author.
+func (s *Store) article(stream []*pb.Event) *pb.Article {
+	a := fold(stream)
+	if author := s.author(a.AuthorId); author != nil {
+		a.AuthorName = author.Name
+	}
+	return a
+}
+
+// author must be called while holding the lock. It returns nil if the author doesn't exist.
+func (s *Store) author(id uint32) *pb.Author {
+	stream, ok := s.authorStreams[id]
+	if !ok {
+		return nil
+	}
+	return foldAuthor(stream)
+}
+
+// append must be called while holding the write lock.
+func (s *Store) append(events []*pb.Event) {
+	if s.streams == nil {
+		s.streams = make(map[uint32][]*pb.Event)
+		s.authorStreams = make(map[uint32][]*pb.Event)
+		s.keys = make(map[string]uint32)
+	}
+	for _, e := range events {
+		e.Position = uint64(len(s.log) + 1)
+		s.log = append(s.log, e)
+		if e.AggregateType == authorType {
+			s.authorStreams[e.AggregateId] = append(s.authorStreams[e.AggregateId], e)
+		} else {
+			s.streams[e.AggregateId] = append(s.streams[e.AggregateId], e)
+			if e.AggregateId > s.lastID {
+				s.lastID = e.AggregateId
+			}
+			if e.IdempotencyKey != "" {
+				s.keys[e.IdempotencyKey] = e.AggregateId
+			}
+		}
+	}
+	if s.appended != nil && len(events) > 0 {
+		close(s.appended)
+		s.appended = nil
+	}
+}
+
+// authorStore implements authors.Factory on top of the streams of authors in Store.
+type authorStore struct {
+	s *Store
+}
+
+// Get returns an author by ID
+func (as authorStore) Get(ctx context.Context, id uint32) (*pb.Author, error) {
+	as.s.RLock()
+	defer as.s.RUnlock()
+
+	a := as.s.author(id)
+	if a == nil {
+		return nil, authors.ErrAuthorNotFound
+	}
+	return a, nil
+}
+
+// Create appends the events of a new author to a new stream
+func (as authorStore) Create(ctx context.Context, a *pb.Author) (*pb.Author, error) {
+	as.s.Lock()
+	defer as.s.Unlock()
+
+	if as.s.author(a.Id) != nil {
+		return nil, authors.ErrAuthorExists
+	}
+	as.s.append(newEvents(ctx, a.Id, 0, authorCreated(a)...))
+	return as.s.author(a.Id), nil
+}
+
+// Update appends the changes of an existing author to its stream
+// unless the author has been modified since the expected version.
+func (as authorStore) Update(ctx context.Context, a *pb.Author, version uint32) (*pb.Author, error) {
+	as.s.Lock()
+	defer as.s.Unlock()
+
+	cur := as.s.author(a.Id)
+	if cur == nil {
+		return nil, authors.ErrAuthorNotFound
+	}
+	if cur.Version != version {
+		return nil, authors.ErrVersionConflict
+	}
+	as.s.append(newEvents(ctx, a.Id, cur.Version, authorChanges(cur, a)...))
+	return as.s.author(a.Id), nil
+}
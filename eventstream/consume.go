@@ -0,0 +1,67 @@
+package eventstream
+
+import (
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+const (
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// Handler processes a single event. The position of the event is
+// acknowledged as soon as the handler returns without an error.
+type Handler func(ctx context.Context, e *pb.Event) error
+
+// Consume subscribes to the events of the articles service and passes them to h one by one.
+// When the stream breaks, Consume reconnects and resumes after the last acknowledged position.
+// It returns when ctx is done, when h fails or when the stream fails for good, e.g. because
+// the requested position is no longer available. The state of the stream is reported to st, which may be nil.
+func Consume(ctx context.Context, c pb.ArticlesClient, req *pb.SubscribeEventsRequest, h Handler, st *Status) error {
+	req = proto.Clone(req).(*pb.SubscribeEventsRequest)
+	backoff := minBackoff
+	for {
+		stream, err := c.SubscribeEvents(ctx, req)
+		if err == nil {
+			// the service sends the header as soon as it accepts the subscription
+			if _, err = stream.Header(); err == nil {
+				st.set(nil)
+			}
+		}
+		for err == nil {
+			var e *pb.Event
+			if e, err = stream.Recv(); err != nil {
+				break
+			}
+			if err := h(ctx, e); err != nil {
+				return err
+			}
+			// the log ID of the request is kept, so a log replaced in the meantime is not resumed
+			req.Start, req.Position = pb.SubscribeEventsRequest_AFTER, e.Position
+			backoff = minBackoff
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		st.set(err)
+		if !retryable(err) {
+			return err
+		}
+		log.Printf("event stream broke, reconnecting in %v: %v\n", backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package eventstream
+
+import (
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryAfter is how many seconds the HTTP clients are asked to wait while the stream is broken.
+const retryAfter = "30"
+
+// Status is the state of an event stream. It is shared with the HTTP handlers which serve the
+// projection fed by the stream, so they can tell a projection which is behind from one which is current.
+type Status struct {
+	err error
+	mu  sync.RWMutex
+}
+
+// Err returns the error which broke the stream, or nil while the stream is connected.
+func (s *Status) Err() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.err
+}
+
+func (s *Status) set(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// retryable reports whether the stream may be resumed after err, e.g. after the articles service restarts.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// HTTPStatus returns the HTTP status code of the gRPC code of err, as mapped by google.rpc.Code.
+func HTTPStatus(err error) int {
+	switch status.Code(err) {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// Error replies to the request with the HTTP status of err. The clients are asked to retry later
+// if the articles service is unavailable.
+func Error(w http.ResponseWriter, err error) {
+	code := HTTPStatus(err)
+	if code == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+	http.Error(w, http.StatusText(code), code)
+}
@@ -11,10 +11,44 @@ It has these top-level messages:
 	ArticleRequest
 	ArticleReply
 	ArticlesReply
+	BatchGetRequest
 	CreateArticleRequest
 	UpdateArticleRequest
+	ListArticleRevisionsRequest
+	GetArticleRevisionRequest
+	RevertArticleRequest
+	PublishArticleRequest
+	RetractArticleRequest
+	UnpublishArticleRequest
+	ArticleRevisionsReply
+	ArticleRevisionReply
+	ArticleRevision
+	FieldChange
 	LatestArticlesRequest
+	SubscribeEventsRequest
+	SearchArticlesRequest
+	SearchArticlesReply
+	SearchHit
+	Snippet
 	Article
+	AuthorRequest
+	AuthorReply
+	AuthorsReply
+	CreateAuthorRequest
+	UpdateAuthorRequest
+	Author
+	Event
+	ArticleCreated
+	ArticleUpdated
+	ArticleDrafted
+	ArticlePublished
+	ArticleScheduled
+	ArticleRetracted
+	ArticleRetitled
+	ArticleRecategorised
+	AuthorCreated
+	AuthorUpdated
+	AuthorRenamed
 */
 package publishing
 
@@ -46,6 +80,8 @@ const (
 	ArticleStatus_DRAFT     ArticleStatus = 1
 	ArticleStatus_PUBLISHED ArticleStatus = 2
 	ArticleStatus_RETRACTED ArticleStatus = 3
+	// SCHEDULED articles are published automatically at their publish_at time
+	ArticleStatus_SCHEDULED ArticleStatus = 4
 )
 
 var ArticleStatus_name = map[int32]string{
@@ -53,12 +89,14 @@ var ArticleStatus_name = map[int32]string{
 	1: "DRAFT",
 	2: "PUBLISHED",
 	3: "RETRACTED",
+	4: "SCHEDULED",
 }
 var ArticleStatus_value = map[string]int32{
 	"UNKNOWN":   0,
 	"DRAFT":     1,
 	"PUBLISHED": 2,
 	"RETRACTED": 3,
+	"SCHEDULED": 4,
 }
 
 func (x ArticleStatus) String() string {
@@ -66,6 +104,35 @@ func (x ArticleStatus) String() string {
 }
 func (ArticleStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+type SubscribeEventsRequest_Start int32
+
+const (
+	// BEGINNING streams all events ever recorded
+	SubscribeEventsRequest_BEGINNING SubscribeEventsRequest_Start = 0
+	// LATEST streams only the events recorded after the subscription started
+	SubscribeEventsRequest_LATEST SubscribeEventsRequest_Start = 1
+	// AFTER streams the events recorded after position
+	SubscribeEventsRequest_AFTER SubscribeEventsRequest_Start = 2
+)
+
+var SubscribeEventsRequest_Start_name = map[int32]string{
+	0: "BEGINNING",
+	1: "LATEST",
+	2: "AFTER",
+}
+var SubscribeEventsRequest_Start_value = map[string]int32{
+	"BEGINNING": 0,
+	"LATEST":    1,
+	"AFTER":     2,
+}
+
+func (x SubscribeEventsRequest_Start) String() string {
+	return proto.EnumName(SubscribeEventsRequest_Start_name, int32(x))
+}
+func (SubscribeEventsRequest_Start) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{17, 0}
+}
+
 type ArticleRequest struct {
 	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 }
@@ -100,6 +167,10 @@ func (m *ArticleReply) GetArticle() *Article {
 
 type ArticlesReply struct {
 	Articles []*Article `protobuf:"bytes,1,rep,name=articles" json:"articles,omitempty"`
+	// next_page_token fetches the following page, it is empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken" json:"next_page_token,omitempty"`
+	// cursors[i] is the page token of the page which starts right after articles[i]
+	Cursors []string `protobuf:"bytes,3,rep,name=cursors" json:"cursors,omitempty"`
 }
 
 func (m *ArticlesReply) Reset()                    { *m = ArticlesReply{} }
@@ -114,14 +185,51 @@ func (m *ArticlesReply) GetArticles() []*Article {
 	return nil
 }
 
+func (m *ArticlesReply) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *ArticlesReply) GetCursors() []string {
+	if m != nil {
+		return m.Cursors
+	}
+	return nil
+}
+
+// BatchGetRequest selects entities by ID. The entities which don't exist are left out of the reply.
+type BatchGetRequest struct {
+	Ids []uint32 `protobuf:"varint,1,rep,packed,name=ids" json:"ids,omitempty"`
+}
+
+func (m *BatchGetRequest) Reset()                    { *m = BatchGetRequest{} }
+func (m *BatchGetRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchGetRequest) ProtoMessage()               {}
+func (*BatchGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *BatchGetRequest) GetIds() []uint32 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
 type CreateArticleRequest struct {
+	// article is created with the next free ID, its id, created and modified are set by the server
 	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+	// editor is who creates the article, it is recorded in the revision history
+	Editor string `protobuf:"bytes,2,opt,name=editor" json:"editor,omitempty"`
+	// idempotency_key makes the retries safe: the requests with the key of an article created
+	// before get that article instead of creating another one
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey" json:"idempotency_key,omitempty"`
 }
 
 func (m *CreateArticleRequest) Reset()                    { *m = CreateArticleRequest{} }
 func (m *CreateArticleRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateArticleRequest) ProtoMessage()               {}
-func (*CreateArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*CreateArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *CreateArticleRequest) GetArticle() *Article {
 	if m != nil {
@@ -130,14 +238,32 @@ func (m *CreateArticleRequest) GetArticle() *Article {
 	return nil
 }
 
+func (m *CreateArticleRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+func (m *CreateArticleRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type UpdateArticleRequest struct {
 	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+	// expected_version is the version of the article the update is based on
+	ExpectedVersion uint32 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+	// editor is who updates the article, it is recorded in the revision history
+	Editor string `protobuf:"bytes,3,opt,name=editor" json:"editor,omitempty"`
 }
 
 func (m *UpdateArticleRequest) Reset()                    { *m = UpdateArticleRequest{} }
 func (m *UpdateArticleRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateArticleRequest) ProtoMessage()               {}
-func (*UpdateArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*UpdateArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *UpdateArticleRequest) GetArticle() *Article {
 	if m != nil {
@@ -146,236 +272,1879 @@ func (m *UpdateArticleRequest) GetArticle() *Article {
 	return nil
 }
 
-type LatestArticlesRequest struct {
-	Status   ArticleStatus `protobuf:"varint,1,opt,name=status,enum=publishing.ArticleStatus" json:"status,omitempty"`
-	Count    uint32        `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
-	Category string        `protobuf:"bytes,3,opt,name=category" json:"category,omitempty"`
+func (m *UpdateArticleRequest) GetExpectedVersion() uint32 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
 }
 
-func (m *LatestArticlesRequest) Reset()                    { *m = LatestArticlesRequest{} }
-func (m *LatestArticlesRequest) String() string            { return proto.CompactTextString(m) }
-func (*LatestArticlesRequest) ProtoMessage()               {}
-func (*LatestArticlesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *UpdateArticleRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
 
-func (m *LatestArticlesRequest) GetStatus() ArticleStatus {
+type ListArticleRevisionsRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *ListArticleRevisionsRequest) Reset()                    { *m = ListArticleRevisionsRequest{} }
+func (m *ListArticleRevisionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListArticleRevisionsRequest) ProtoMessage()               {}
+func (*ListArticleRevisionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *ListArticleRevisionsRequest) GetId() uint32 {
 	if m != nil {
-		return m.Status
+		return m.Id
 	}
-	return ArticleStatus_UNKNOWN
+	return 0
 }
 
-func (m *LatestArticlesRequest) GetCount() uint32 {
+type GetArticleRevisionRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// version is the version of the article after the revision
+	Version uint32 `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *GetArticleRevisionRequest) Reset()                    { *m = GetArticleRevisionRequest{} }
+func (m *GetArticleRevisionRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetArticleRevisionRequest) ProtoMessage()               {}
+func (*GetArticleRevisionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *GetArticleRevisionRequest) GetId() uint32 {
 	if m != nil {
-		return m.Count
+		return m.Id
 	}
 	return 0
 }
 
-func (m *LatestArticlesRequest) GetCategory() string {
+func (m *GetArticleRevisionRequest) GetVersion() uint32 {
 	if m != nil {
-		return m.Category
+		return m.Version
 	}
-	return ""
+	return 0
 }
 
-type Article struct {
-	Id         uint32                     `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Title      string                     `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
-	Body       string                     `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
-	Category   string                     `protobuf:"bytes,4,opt,name=category" json:"category,omitempty"`
-	AuthorId   uint32                     `protobuf:"varint,5,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
-	AuthorName string                     `protobuf:"bytes,6,opt,name=author_name,json=authorName" json:"author_name,omitempty"`
-	Created    *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=created" json:"created,omitempty"`
-	Modified   *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=modified" json:"modified,omitempty"`
-	Status     ArticleStatus              `protobuf:"varint,9,opt,name=status,enum=publishing.ArticleStatus" json:"status,omitempty"`
+type RevertArticleRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// version is the version of the article after the revision which is restored
+	Version uint32 `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	// expected_version is the current version of the article
+	ExpectedVersion uint32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+	// editor is who reverts the article, it is recorded in the revision history
+	Editor string `protobuf:"bytes,4,opt,name=editor" json:"editor,omitempty"`
 }
 
-func (m *Article) Reset()                    { *m = Article{} }
-func (m *Article) String() string            { return proto.CompactTextString(m) }
-func (*Article) ProtoMessage()               {}
-func (*Article) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (m *RevertArticleRequest) Reset()                    { *m = RevertArticleRequest{} }
+func (m *RevertArticleRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevertArticleRequest) ProtoMessage()               {}
+func (*RevertArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-func (m *Article) GetId() uint32 {
+func (m *RevertArticleRequest) GetId() uint32 {
 	if m != nil {
 		return m.Id
 	}
 	return 0
 }
 
-func (m *Article) GetTitle() string {
+func (m *RevertArticleRequest) GetVersion() uint32 {
 	if m != nil {
-		return m.Title
+		return m.Version
 	}
-	return ""
+	return 0
 }
 
-func (m *Article) GetBody() string {
+func (m *RevertArticleRequest) GetExpectedVersion() uint32 {
 	if m != nil {
-		return m.Body
+		return m.ExpectedVersion
 	}
-	return ""
+	return 0
 }
 
-func (m *Article) GetCategory() string {
+func (m *RevertArticleRequest) GetEditor() string {
 	if m != nil {
-		return m.Category
+		return m.Editor
 	}
 	return ""
 }
 
-func (m *Article) GetAuthorId() uint32 {
+type PublishArticleRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// expected_version is the current version of the article
+	ExpectedVersion uint32 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+	// reason is recorded in the revision history
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+	// editor is who publishes the article, it is recorded in the revision history
+	Editor string `protobuf:"bytes,4,opt,name=editor" json:"editor,omitempty"`
+}
+
+func (m *PublishArticleRequest) Reset()                    { *m = PublishArticleRequest{} }
+func (m *PublishArticleRequest) String() string            { return proto.CompactTextString(m) }
+func (*PublishArticleRequest) ProtoMessage()               {}
+func (*PublishArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *PublishArticleRequest) GetId() uint32 {
 	if m != nil {
-		return m.AuthorId
+		return m.Id
 	}
 	return 0
 }
 
-func (m *Article) GetAuthorName() string {
+func (m *PublishArticleRequest) GetExpectedVersion() uint32 {
 	if m != nil {
-		return m.AuthorName
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+func (m *PublishArticleRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
 	return ""
 }
 
-func (m *Article) GetCreated() *google_protobuf.Timestamp {
+func (m *PublishArticleRequest) GetEditor() string {
 	if m != nil {
-		return m.Created
+		return m.Editor
 	}
-	return nil
+	return ""
 }
 
-func (m *Article) GetModified() *google_protobuf.Timestamp {
+type RetractArticleRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// expected_version is the current version of the article
+	ExpectedVersion uint32 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+	// reason is recorded in the revision history
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+	// editor is who retracts the article, it is recorded in the revision history
+	Editor string `protobuf:"bytes,4,opt,name=editor" json:"editor,omitempty"`
+}
+
+func (m *RetractArticleRequest) Reset()                    { *m = RetractArticleRequest{} }
+func (m *RetractArticleRequest) String() string            { return proto.CompactTextString(m) }
+func (*RetractArticleRequest) ProtoMessage()               {}
+func (*RetractArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *RetractArticleRequest) GetId() uint32 {
 	if m != nil {
-		return m.Modified
+		return m.Id
 	}
-	return nil
+	return 0
 }
 
-func (m *Article) GetStatus() ArticleStatus {
+func (m *RetractArticleRequest) GetExpectedVersion() uint32 {
 	if m != nil {
-		return m.Status
+		return m.ExpectedVersion
 	}
-	return ArticleStatus_UNKNOWN
+	return 0
 }
 
-func init() {
-	proto.RegisterType((*ArticleRequest)(nil), "publishing.ArticleRequest")
-	proto.RegisterType((*ArticleReply)(nil), "publishing.ArticleReply")
-	proto.RegisterType((*ArticlesReply)(nil), "publishing.ArticlesReply")
-	proto.RegisterType((*CreateArticleRequest)(nil), "publishing.CreateArticleRequest")
-	proto.RegisterType((*UpdateArticleRequest)(nil), "publishing.UpdateArticleRequest")
-	proto.RegisterType((*LatestArticlesRequest)(nil), "publishing.LatestArticlesRequest")
-	proto.RegisterType((*Article)(nil), "publishing.Article")
-	proto.RegisterEnum("publishing.ArticleStatus", ArticleStatus_name, ArticleStatus_value)
+func (m *RetractArticleRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *RetractArticleRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type UnpublishArticleRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// expected_version is the current version of the article
+	ExpectedVersion uint32 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+	// reason is recorded in the revision history
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+	// editor is who unpublishes the article, it is recorded in the revision history
+	Editor string `protobuf:"bytes,4,opt,name=editor" json:"editor,omitempty"`
+}
 
-// Client API for Articles service
+func (m *UnpublishArticleRequest) Reset()                    { *m = UnpublishArticleRequest{} }
+func (m *UnpublishArticleRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnpublishArticleRequest) ProtoMessage()               {}
+func (*UnpublishArticleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-type ArticlesClient interface {
-	// Article returns a single article by ID
-	Article(ctx context.Context, in *ArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
-	// CreateArticle creates an article
-	CreateArticle(ctx context.Context, in *CreateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
-	// UpdateArticle updates existing article
-	UpdateArticle(ctx context.Context, in *UpdateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
-	// LatestArticles queries for latest articles by the given params
-	LatestArticles(ctx context.Context, in *LatestArticlesRequest, opts ...grpc.CallOption) (*ArticlesReply, error)
+func (m *UnpublishArticleRequest) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
 }
 
-type articlesClient struct {
-	cc *grpc.ClientConn
+func (m *UnpublishArticleRequest) GetExpectedVersion() uint32 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
 }
 
-func NewArticlesClient(cc *grpc.ClientConn) ArticlesClient {
-	return &articlesClient{cc}
+func (m *UnpublishArticleRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
 }
 
-func (c *articlesClient) Article(ctx context.Context, in *ArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
-	out := new(ArticleReply)
-	err := grpc.Invoke(ctx, "/publishing.Articles/Article", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *UnpublishArticleRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
 	}
-	return out, nil
+	return ""
 }
 
-func (c *articlesClient) CreateArticle(ctx context.Context, in *CreateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
-	out := new(ArticleReply)
-	err := grpc.Invoke(ctx, "/publishing.Articles/CreateArticle", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ArticleRevisionsReply struct {
+	Revisions []*ArticleRevision `protobuf:"bytes,1,rep,name=revisions" json:"revisions,omitempty"`
 }
 
-func (c *articlesClient) UpdateArticle(ctx context.Context, in *UpdateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
-	out := new(ArticleReply)
-	err := grpc.Invoke(ctx, "/publishing.Articles/UpdateArticle", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ArticleRevisionsReply) Reset()                    { *m = ArticleRevisionsReply{} }
+func (m *ArticleRevisionsReply) String() string            { return proto.CompactTextString(m) }
+func (*ArticleRevisionsReply) ProtoMessage()               {}
+func (*ArticleRevisionsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ArticleRevisionsReply) GetRevisions() []*ArticleRevision {
+	if m != nil {
+		return m.Revisions
 	}
-	return out, nil
+	return nil
 }
 
-func (c *articlesClient) LatestArticles(ctx context.Context, in *LatestArticlesRequest, opts ...grpc.CallOption) (*ArticlesReply, error) {
-	out := new(ArticlesReply)
-	err := grpc.Invoke(ctx, "/publishing.Articles/LatestArticles", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ArticleRevisionReply struct {
+	Revision *ArticleRevision `protobuf:"bytes,1,opt,name=revision" json:"revision,omitempty"`
 }
 
-// Server API for Articles service
+func (m *ArticleRevisionReply) Reset()                    { *m = ArticleRevisionReply{} }
+func (m *ArticleRevisionReply) String() string            { return proto.CompactTextString(m) }
+func (*ArticleRevisionReply) ProtoMessage()               {}
+func (*ArticleRevisionReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
-type ArticlesServer interface {
-	// Article returns a single article by ID
-	Article(context.Context, *ArticleRequest) (*ArticleReply, error)
-	// CreateArticle creates an article
-	CreateArticle(context.Context, *CreateArticleRequest) (*ArticleReply, error)
-	// UpdateArticle updates existing article
-	UpdateArticle(context.Context, *UpdateArticleRequest) (*ArticleReply, error)
-	// LatestArticles queries for latest articles by the given params
-	LatestArticles(context.Context, *LatestArticlesRequest) (*ArticlesReply, error)
+func (m *ArticleRevisionReply) GetRevision() *ArticleRevision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
 }
 
-func RegisterArticlesServer(s *grpc.Server, srv ArticlesServer) {
-	s.RegisterService(&_Articles_serviceDesc, srv)
+// ArticleRevision is a change of an article made by a single request.
+type ArticleRevision struct {
+	// id is the ID of the last event of the revision
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// version is the version of the article after the revision
+	Version uint32 `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	// article is the state of the article after the revision
+	Article *Article `protobuf:"bytes,3,opt,name=article" json:"article,omitempty"`
+	// editor is who made the change, it is empty if unknown
+	Editor  string                     `protobuf:"bytes,4,opt,name=editor" json:"editor,omitempty"`
+	Created *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=created" json:"created,omitempty"`
+	// changes are the fields which differ from the previous revision
+	Changes []*FieldChange `protobuf:"bytes,6,rep,name=changes" json:"changes,omitempty"`
+	// reverted_version is the version restored by the revision, it is 0 unless the revision is a revert
+	RevertedVersion uint32 `protobuf:"varint,7,opt,name=reverted_version,json=revertedVersion" json:"reverted_version,omitempty"`
+	// reason is why the change was made, it is empty if unknown
+	Reason string `protobuf:"bytes,8,opt,name=reason" json:"reason,omitempty"`
 }
 
-func _Articles_Article_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ArticleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ArticlesServer).Article(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/publishing.Articles/Article",
+func (m *ArticleRevision) Reset()                    { *m = ArticleRevision{} }
+func (m *ArticleRevision) String() string            { return proto.CompactTextString(m) }
+func (*ArticleRevision) ProtoMessage()               {}
+func (*ArticleRevision) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ArticleRevision) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticlesServer).Article(ctx, req.(*ArticleRequest))
+	return ""
+}
+
+func (m *ArticleRevision) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Articles_CreateArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateArticleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ArticleRevision) GetArticle() *Article {
+	if m != nil {
+		return m.Article
 	}
-	if interceptor == nil {
-		return srv.(ArticlesServer).CreateArticle(ctx, in)
+	return nil
+}
+
+func (m *ArticleRevision) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+func (m *ArticleRevision) GetCreated() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *ArticleRevision) GetChanges() []*FieldChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ArticleRevision) GetRevertedVersion() uint32 {
+	if m != nil {
+		return m.RevertedVersion
+	}
+	return 0
+}
+
+func (m *ArticleRevision) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// FieldChange is a change of a single field of an article.
+type FieldChange struct {
+	// field is the name of the field, e.g. "title"
+	Field         string `protobuf:"bytes,1,opt,name=field" json:"field,omitempty"`
+	PreviousValue string `protobuf:"bytes,2,opt,name=previous_value,json=previousValue" json:"previous_value,omitempty"`
+	Value         string `protobuf:"bytes,3,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *FieldChange) Reset()                    { *m = FieldChange{} }
+func (m *FieldChange) String() string            { return proto.CompactTextString(m) }
+func (*FieldChange) ProtoMessage()               {}
+func (*FieldChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *FieldChange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *FieldChange) GetPreviousValue() string {
+	if m != nil {
+		return m.PreviousValue
+	}
+	return ""
+}
+
+func (m *FieldChange) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type LatestArticlesRequest struct {
+	Status   ArticleStatus `protobuf:"varint,1,opt,name=status,enum=publishing.ArticleStatus" json:"status,omitempty"`
+	Count    uint32        `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	Category string        `protobuf:"bytes,3,opt,name=category" json:"category,omitempty"`
+	// page_token is the next_page_token or one of the cursors of a previous reply
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
+	// author_id returns the articles of a single author if not 0
+	AuthorId uint32 `protobuf:"varint,5,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
+}
+
+func (m *LatestArticlesRequest) Reset()                    { *m = LatestArticlesRequest{} }
+func (m *LatestArticlesRequest) String() string            { return proto.CompactTextString(m) }
+func (*LatestArticlesRequest) ProtoMessage()               {}
+func (*LatestArticlesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *LatestArticlesRequest) GetStatus() ArticleStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ArticleStatus_UNKNOWN
+}
+
+func (m *LatestArticlesRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *LatestArticlesRequest) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *LatestArticlesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *LatestArticlesRequest) GetAuthorId() uint32 {
+	if m != nil {
+		return m.AuthorId
+	}
+	return 0
+}
+
+type SubscribeEventsRequest struct {
+	Start SubscribeEventsRequest_Start `protobuf:"varint,1,opt,name=start,enum=publishing.SubscribeEventsRequest_Start" json:"start,omitempty"`
+	// position of the last acknowledged event, used when start is AFTER
+	Position uint64 `protobuf:"varint,2,opt,name=position" json:"position,omitempty"`
+	// log_id is the ID of the first event of the log the position refers to. If it is set and the log
+	// starts with another event, e.g. because it has been wiped since, the subscription fails with FAILED_PRECONDITION.
+	LogId string `protobuf:"bytes,3,opt,name=log_id,json=logId" json:"log_id,omitempty"`
+}
+
+func (m *SubscribeEventsRequest) Reset()                    { *m = SubscribeEventsRequest{} }
+func (m *SubscribeEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeEventsRequest) ProtoMessage()               {}
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *SubscribeEventsRequest) GetStart() SubscribeEventsRequest_Start {
+	if m != nil {
+		return m.Start
+	}
+	return SubscribeEventsRequest_BEGINNING
+}
+
+func (m *SubscribeEventsRequest) GetPosition() uint64 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *SubscribeEventsRequest) GetLogId() string {
+	if m != nil {
+		return m.LogId
+	}
+	return ""
+}
+
+type SearchArticlesRequest struct {
+	Query    string        `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
+	Category string        `protobuf:"bytes,2,opt,name=category" json:"category,omitempty"`
+	Status   ArticleStatus `protobuf:"varint,3,opt,name=status,enum=publishing.ArticleStatus" json:"status,omitempty"`
+	Count    uint32        `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
+	Offset   uint32        `protobuf:"varint,5,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *SearchArticlesRequest) Reset()                    { *m = SearchArticlesRequest{} }
+func (m *SearchArticlesRequest) String() string            { return proto.CompactTextString(m) }
+func (*SearchArticlesRequest) ProtoMessage()               {}
+func (*SearchArticlesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *SearchArticlesRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchArticlesRequest) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *SearchArticlesRequest) GetStatus() ArticleStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ArticleStatus_UNKNOWN
+}
+
+func (m *SearchArticlesRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *SearchArticlesRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type SearchArticlesReply struct {
+	Hits []*SearchHit `protobuf:"bytes,1,rep,name=hits" json:"hits,omitempty"`
+	// total is the number of all matching articles
+	Total uint64 `protobuf:"varint,2,opt,name=total" json:"total,omitempty"`
+}
+
+func (m *SearchArticlesReply) Reset()                    { *m = SearchArticlesReply{} }
+func (m *SearchArticlesReply) String() string            { return proto.CompactTextString(m) }
+func (*SearchArticlesReply) ProtoMessage()               {}
+func (*SearchArticlesReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *SearchArticlesReply) GetHits() []*SearchHit {
+	if m != nil {
+		return m.Hits
+	}
+	return nil
+}
+
+func (m *SearchArticlesReply) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type SearchHit struct {
+	Article  *Article   `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+	Score    float64    `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
+	Snippets []*Snippet `protobuf:"bytes,3,rep,name=snippets" json:"snippets,omitempty"`
+}
+
+func (m *SearchHit) Reset()                    { *m = SearchHit{} }
+func (m *SearchHit) String() string            { return proto.CompactTextString(m) }
+func (*SearchHit) ProtoMessage()               {}
+func (*SearchHit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *SearchHit) GetArticle() *Article {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+func (m *SearchHit) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchHit) GetSnippets() []*Snippet {
+	if m != nil {
+		return m.Snippets
+	}
+	return nil
+}
+
+// Snippet contains the fragments of a field with the matched terms highlighted.
+type Snippet struct {
+	Field     string   `protobuf:"bytes,1,opt,name=field" json:"field,omitempty"`
+	Fragments []string `protobuf:"bytes,2,rep,name=fragments" json:"fragments,omitempty"`
+}
+
+func (m *Snippet) Reset()                    { *m = Snippet{} }
+func (m *Snippet) String() string            { return proto.CompactTextString(m) }
+func (*Snippet) ProtoMessage()               {}
+func (*Snippet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *Snippet) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Snippet) GetFragments() []string {
+	if m != nil {
+		return m.Fragments
+	}
+	return nil
+}
+
+type Article struct {
+	Id         uint32                     `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Title      string                     `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
+	Body       string                     `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
+	Category   string                     `protobuf:"bytes,4,opt,name=category" json:"category,omitempty"`
+	AuthorId   uint32                     `protobuf:"varint,5,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
+	AuthorName string                     `protobuf:"bytes,6,opt,name=author_name,json=authorName" json:"author_name,omitempty"`
+	Created    *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=created" json:"created,omitempty"`
+	Modified   *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=modified" json:"modified,omitempty"`
+	Status     ArticleStatus              `protobuf:"varint,9,opt,name=status,enum=publishing.ArticleStatus" json:"status,omitempty"`
+	// version is incremented by every change of the article
+	Version uint32 `protobuf:"varint,10,opt,name=version" json:"version,omitempty"`
+	// published is set by the store when the article is published, it is kept when the article is retracted
+	Published *google_protobuf.Timestamp `protobuf:"bytes,11,opt,name=published" json:"published,omitempty"`
+	// slug identifies the article in its URL. It is derived from the title when the article is created
+	// and kept when the title changes, so that the URL of the article stays the same.
+	Slug string `protobuf:"bytes,12,opt,name=slug" json:"slug,omitempty"`
+	// publish_at is when a SCHEDULED article is published, it is required for the scheduled articles
+	PublishAt *google_protobuf.Timestamp `protobuf:"bytes,13,opt,name=publish_at,json=publishAt" json:"publish_at,omitempty"`
+	// embargo_until keeps a published article out of the listings, the feeds and the sitemaps until it passes
+	EmbargoUntil *google_protobuf.Timestamp `protobuf:"bytes,14,opt,name=embargo_until,json=embargoUntil" json:"embargo_until,omitempty"`
+}
+
+func (m *Article) Reset()                    { *m = Article{} }
+func (m *Article) String() string            { return proto.CompactTextString(m) }
+func (*Article) ProtoMessage()               {}
+func (*Article) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *Article) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Article) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Article) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Article) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *Article) GetAuthorId() uint32 {
+	if m != nil {
+		return m.AuthorId
+	}
+	return 0
+}
+
+func (m *Article) GetAuthorName() string {
+	if m != nil {
+		return m.AuthorName
+	}
+	return ""
+}
+
+func (m *Article) GetCreated() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Article) GetModified() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Modified
+	}
+	return nil
+}
+
+func (m *Article) GetStatus() ArticleStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ArticleStatus_UNKNOWN
+}
+
+func (m *Article) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Article) GetPublished() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Published
+	}
+	return nil
+}
+
+func (m *Article) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *Article) GetPublishAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.PublishAt
+	}
+	return nil
+}
+
+func (m *Article) GetEmbargoUntil() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.EmbargoUntil
+	}
+	return nil
+}
+
+type AuthorRequest struct {
+	Id uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *AuthorRequest) Reset()                    { *m = AuthorRequest{} }
+func (m *AuthorRequest) String() string            { return proto.CompactTextString(m) }
+func (*AuthorRequest) ProtoMessage()               {}
+func (*AuthorRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *AuthorRequest) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type AuthorReply struct {
+	Author *Author `protobuf:"bytes,1,opt,name=author" json:"author,omitempty"`
+}
+
+func (m *AuthorReply) Reset()                    { *m = AuthorReply{} }
+func (m *AuthorReply) String() string            { return proto.CompactTextString(m) }
+func (*AuthorReply) ProtoMessage()               {}
+func (*AuthorReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *AuthorReply) GetAuthor() *Author {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+type AuthorsReply struct {
+	Authors []*Author `protobuf:"bytes,1,rep,name=authors" json:"authors,omitempty"`
+}
+
+func (m *AuthorsReply) Reset()                    { *m = AuthorsReply{} }
+func (m *AuthorsReply) String() string            { return proto.CompactTextString(m) }
+func (*AuthorsReply) ProtoMessage()               {}
+func (*AuthorsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *AuthorsReply) GetAuthors() []*Author {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+type CreateAuthorRequest struct {
+	Author *Author `protobuf:"bytes,1,opt,name=author" json:"author,omitempty"`
+}
+
+func (m *CreateAuthorRequest) Reset()                    { *m = CreateAuthorRequest{} }
+func (m *CreateAuthorRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateAuthorRequest) ProtoMessage()               {}
+func (*CreateAuthorRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *CreateAuthorRequest) GetAuthor() *Author {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+type UpdateAuthorRequest struct {
+	Author *Author `protobuf:"bytes,1,opt,name=author" json:"author,omitempty"`
+	// expected_version is the version of the author the update is based on
+	ExpectedVersion uint32 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+}
+
+func (m *UpdateAuthorRequest) Reset()                    { *m = UpdateAuthorRequest{} }
+func (m *UpdateAuthorRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateAuthorRequest) ProtoMessage()               {}
+func (*UpdateAuthorRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *UpdateAuthorRequest) GetAuthor() *Author {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+func (m *UpdateAuthorRequest) GetExpectedVersion() uint32 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type Author struct {
+	Id        uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Bio       string `protobuf:"bytes,3,opt,name=bio" json:"bio,omitempty"`
+	Email     string `protobuf:"bytes,4,opt,name=email" json:"email,omitempty"`
+	AvatarUrl string `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl" json:"avatar_url,omitempty"`
+	// version is incremented by every change of the author
+	Version uint32 `protobuf:"varint,6,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *Author) Reset()                    { *m = Author{} }
+func (m *Author) String() string            { return proto.CompactTextString(m) }
+func (*Author) ProtoMessage()               {}
+func (*Author) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *Author) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Author) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Author) GetBio() string {
+	if m != nil {
+		return m.Bio
+	}
+	return ""
+}
+
+func (m *Author) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Author) GetAvatarUrl() string {
+	if m != nil {
+		return m.AvatarUrl
+	}
+	return ""
+}
+
+func (m *Author) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// Event is the envelope of every domain event.
+type Event struct {
+	// id uniquely identifies the event
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// aggregate_id is the ID of the entity the event belongs to
+	AggregateId uint32 `protobuf:"varint,2,opt,name=aggregate_id,json=aggregateId" json:"aggregate_id,omitempty"`
+	// aggregate_version is the version of the entity after the event, starting at 1
+	AggregateVersion uint32 `protobuf:"varint,3,opt,name=aggregate_version,json=aggregateVersion" json:"aggregate_version,omitempty"`
+	// type is the fully qualified name of the payload message
+	Type       string                     `protobuf:"bytes,4,opt,name=type" json:"type,omitempty"`
+	OccurredAt *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt" json:"occurred_at,omitempty"`
+	// causation_id is the ID of the event or command which caused this event
+	CausationId string `protobuf:"bytes,6,opt,name=causation_id,json=causationId" json:"causation_id,omitempty"`
+	// correlation_id is shared by all events caused by the same command
+	CorrelationId string `protobuf:"bytes,7,opt,name=correlation_id,json=correlationId" json:"correlation_id,omitempty"`
+	// position is the sequence number of the event in the log of all events, starting at 1
+	Position uint64 `protobuf:"varint,8,opt,name=position" json:"position,omitempty"`
+	// aggregate_type is the fully qualified name of the entity, e.g. "publishing.Article"
+	AggregateType string `protobuf:"bytes,9,opt,name=aggregate_type,json=aggregateType" json:"aggregate_type,omitempty"`
+	// editor is who made the change, it is empty if unknown
+	Editor string `protobuf:"bytes,20,opt,name=editor" json:"editor,omitempty"`
+	// reason is why the change was made, e.g. why an article was retracted
+	Reason string `protobuf:"bytes,22,opt,name=reason" json:"reason,omitempty"`
+	// idempotency_key is the key of the request which created the article, it is empty if unknown
+	IdempotencyKey string `protobuf:"bytes,23,opt,name=idempotency_key,json=idempotencyKey" json:"idempotency_key,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//	*Event_ArticleCreated
+	//	*Event_ArticleUpdated
+	//	*Event_ArticleDrafted
+	//	*Event_ArticlePublished
+	//	*Event_ArticleRetracted
+	//	*Event_ArticleRetitled
+	//	*Event_ArticleRecategorised
+	//	*Event_AuthorCreated
+	//	*Event_AuthorUpdated
+	//	*Event_AuthorRenamed
+	//	*Event_ArticleScheduled
+	Payload isEvent_Payload `protobuf_oneof:"payload"`
+}
+
+func (m *Event) Reset()                    { *m = Event{} }
+func (m *Event) String() string            { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()               {}
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+type isEvent_Payload interface{ isEvent_Payload() }
+
+type Event_ArticleCreated struct {
+	ArticleCreated *ArticleCreated `protobuf:"bytes,10,opt,name=article_created,json=articleCreated,oneof"`
+}
+type Event_ArticleUpdated struct {
+	ArticleUpdated *ArticleUpdated `protobuf:"bytes,11,opt,name=article_updated,json=articleUpdated,oneof"`
+}
+type Event_ArticleDrafted struct {
+	ArticleDrafted *ArticleDrafted `protobuf:"bytes,12,opt,name=article_drafted,json=articleDrafted,oneof"`
+}
+type Event_ArticlePublished struct {
+	ArticlePublished *ArticlePublished `protobuf:"bytes,13,opt,name=article_published,json=articlePublished,oneof"`
+}
+type Event_ArticleRetracted struct {
+	ArticleRetracted *ArticleRetracted `protobuf:"bytes,14,opt,name=article_retracted,json=articleRetracted,oneof"`
+}
+type Event_ArticleRetitled struct {
+	ArticleRetitled *ArticleRetitled `protobuf:"bytes,15,opt,name=article_retitled,json=articleRetitled,oneof"`
+}
+type Event_ArticleRecategorised struct {
+	ArticleRecategorised *ArticleRecategorised `protobuf:"bytes,16,opt,name=article_recategorised,json=articleRecategorised,oneof"`
+}
+type Event_AuthorCreated struct {
+	AuthorCreated *AuthorCreated `protobuf:"bytes,17,opt,name=author_created,json=authorCreated,oneof"`
+}
+type Event_AuthorUpdated struct {
+	AuthorUpdated *AuthorUpdated `protobuf:"bytes,18,opt,name=author_updated,json=authorUpdated,oneof"`
+}
+type Event_AuthorRenamed struct {
+	AuthorRenamed *AuthorRenamed `protobuf:"bytes,19,opt,name=author_renamed,json=authorRenamed,oneof"`
+}
+type Event_ArticleScheduled struct {
+	ArticleScheduled *ArticleScheduled `protobuf:"bytes,21,opt,name=article_scheduled,json=articleScheduled,oneof"`
+}
+
+func (*Event_ArticleCreated) isEvent_Payload()       {}
+func (*Event_ArticleUpdated) isEvent_Payload()       {}
+func (*Event_ArticleDrafted) isEvent_Payload()       {}
+func (*Event_ArticlePublished) isEvent_Payload()     {}
+func (*Event_ArticleRetracted) isEvent_Payload()     {}
+func (*Event_ArticleRetitled) isEvent_Payload()      {}
+func (*Event_ArticleRecategorised) isEvent_Payload() {}
+func (*Event_AuthorCreated) isEvent_Payload()        {}
+func (*Event_AuthorUpdated) isEvent_Payload()        {}
+func (*Event_AuthorRenamed) isEvent_Payload()        {}
+func (*Event_ArticleScheduled) isEvent_Payload()     {}
+
+func (m *Event) GetPayload() isEvent_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *Event) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Event) GetAggregateId() uint32 {
+	if m != nil {
+		return m.AggregateId
+	}
+	return 0
+}
+
+func (m *Event) GetAggregateVersion() uint32 {
+	if m != nil {
+		return m.AggregateVersion
+	}
+	return 0
+}
+
+func (m *Event) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Event) GetOccurredAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return nil
+}
+
+func (m *Event) GetCausationId() string {
+	if m != nil {
+		return m.CausationId
+	}
+	return ""
+}
+
+func (m *Event) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *Event) GetPosition() uint64 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *Event) GetAggregateType() string {
+	if m != nil {
+		return m.AggregateType
+	}
+	return ""
+}
+
+func (m *Event) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+func (m *Event) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Event) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *Event) GetArticleCreated() *ArticleCreated {
+	if x, ok := m.GetPayload().(*Event_ArticleCreated); ok {
+		return x.ArticleCreated
+	}
+	return nil
+}
+
+func (m *Event) GetArticleUpdated() *ArticleUpdated {
+	if x, ok := m.GetPayload().(*Event_ArticleUpdated); ok {
+		return x.ArticleUpdated
+	}
+	return nil
+}
+
+func (m *Event) GetArticleDrafted() *ArticleDrafted {
+	if x, ok := m.GetPayload().(*Event_ArticleDrafted); ok {
+		return x.ArticleDrafted
+	}
+	return nil
+}
+
+func (m *Event) GetArticlePublished() *ArticlePublished {
+	if x, ok := m.GetPayload().(*Event_ArticlePublished); ok {
+		return x.ArticlePublished
+	}
+	return nil
+}
+
+func (m *Event) GetArticleRetracted() *ArticleRetracted {
+	if x, ok := m.GetPayload().(*Event_ArticleRetracted); ok {
+		return x.ArticleRetracted
+	}
+	return nil
+}
+
+func (m *Event) GetArticleRetitled() *ArticleRetitled {
+	if x, ok := m.GetPayload().(*Event_ArticleRetitled); ok {
+		return x.ArticleRetitled
+	}
+	return nil
+}
+
+func (m *Event) GetArticleRecategorised() *ArticleRecategorised {
+	if x, ok := m.GetPayload().(*Event_ArticleRecategorised); ok {
+		return x.ArticleRecategorised
+	}
+	return nil
+}
+
+func (m *Event) GetAuthorCreated() *AuthorCreated {
+	if x, ok := m.GetPayload().(*Event_AuthorCreated); ok {
+		return x.AuthorCreated
+	}
+	return nil
+}
+
+func (m *Event) GetAuthorUpdated() *AuthorUpdated {
+	if x, ok := m.GetPayload().(*Event_AuthorUpdated); ok {
+		return x.AuthorUpdated
+	}
+	return nil
+}
+
+func (m *Event) GetAuthorRenamed() *AuthorRenamed {
+	if x, ok := m.GetPayload().(*Event_AuthorRenamed); ok {
+		return x.AuthorRenamed
+	}
+	return nil
+}
+
+func (m *Event) GetArticleScheduled() *ArticleScheduled {
+	if x, ok := m.GetPayload().(*Event_ArticleScheduled); ok {
+		return x.ArticleScheduled
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Event) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Event_OneofMarshaler, _Event_OneofUnmarshaler, _Event_OneofSizer, []interface{}{
+		(*Event_ArticleCreated)(nil),
+		(*Event_ArticleUpdated)(nil),
+		(*Event_ArticleDrafted)(nil),
+		(*Event_ArticlePublished)(nil),
+		(*Event_ArticleRetracted)(nil),
+		(*Event_ArticleRetitled)(nil),
+		(*Event_ArticleRecategorised)(nil),
+		(*Event_AuthorCreated)(nil),
+		(*Event_AuthorUpdated)(nil),
+		(*Event_AuthorRenamed)(nil),
+		(*Event_ArticleScheduled)(nil),
+	}
+}
+
+func _Event_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Event)
+	// payload
+	switch x := m.Payload.(type) {
+	case *Event_ArticleCreated:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticleCreated); err != nil {
+			return err
+		}
+	case *Event_ArticleUpdated:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticleUpdated); err != nil {
+			return err
+		}
+	case *Event_ArticleDrafted:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticleDrafted); err != nil {
+			return err
+		}
+	case *Event_ArticlePublished:
+		b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticlePublished); err != nil {
+			return err
+		}
+	case *Event_ArticleRetracted:
+		b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticleRetracted); err != nil {
+			return err
+		}
+	case *Event_ArticleRetitled:
+		b.EncodeVarint(15<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticleRetitled); err != nil {
+			return err
+		}
+	case *Event_ArticleRecategorised:
+		b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticleRecategorised); err != nil {
+			return err
+		}
+	case *Event_AuthorCreated:
+		b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AuthorCreated); err != nil {
+			return err
+		}
+	case *Event_AuthorUpdated:
+		b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AuthorUpdated); err != nil {
+			return err
+		}
+	case *Event_AuthorRenamed:
+		b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AuthorRenamed); err != nil {
+			return err
+		}
+	case *Event_ArticleScheduled:
+		b.EncodeVarint(21<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArticleScheduled); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Event.Payload has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Event_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Event)
+	switch tag {
+	case 10: // payload.article_created
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticleCreated)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticleCreated{msg}
+		return true, err
+	case 11: // payload.article_updated
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticleUpdated)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticleUpdated{msg}
+		return true, err
+	case 12: // payload.article_drafted
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticleDrafted)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticleDrafted{msg}
+		return true, err
+	case 13: // payload.article_published
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticlePublished)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticlePublished{msg}
+		return true, err
+	case 14: // payload.article_retracted
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticleRetracted)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticleRetracted{msg}
+		return true, err
+	case 15: // payload.article_retitled
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticleRetitled)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticleRetitled{msg}
+		return true, err
+	case 16: // payload.article_recategorised
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticleRecategorised)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticleRecategorised{msg}
+		return true, err
+	case 17: // payload.author_created
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AuthorCreated)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_AuthorCreated{msg}
+		return true, err
+	case 18: // payload.author_updated
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AuthorUpdated)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_AuthorUpdated{msg}
+		return true, err
+	case 19: // payload.author_renamed
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AuthorRenamed)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_AuthorRenamed{msg}
+		return true, err
+	case 21: // payload.article_scheduled
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ArticleScheduled)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Event_ArticleScheduled{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Event_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Event)
+	// payload
+	switch x := m.Payload.(type) {
+	case *Event_ArticleCreated:
+		s := proto.Size(x.ArticleCreated)
+		n += proto.SizeVarint(10<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_ArticleUpdated:
+		s := proto.Size(x.ArticleUpdated)
+		n += proto.SizeVarint(11<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_ArticleDrafted:
+		s := proto.Size(x.ArticleDrafted)
+		n += proto.SizeVarint(12<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_ArticlePublished:
+		s := proto.Size(x.ArticlePublished)
+		n += proto.SizeVarint(13<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_ArticleRetracted:
+		s := proto.Size(x.ArticleRetracted)
+		n += proto.SizeVarint(14<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_ArticleRetitled:
+		s := proto.Size(x.ArticleRetitled)
+		n += proto.SizeVarint(15<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_ArticleRecategorised:
+		s := proto.Size(x.ArticleRecategorised)
+		n += proto.SizeVarint(16<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_AuthorCreated:
+		s := proto.Size(x.AuthorCreated)
+		n += proto.SizeVarint(17<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_AuthorUpdated:
+		s := proto.Size(x.AuthorUpdated)
+		n += proto.SizeVarint(18<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_AuthorRenamed:
+		s := proto.Size(x.AuthorRenamed)
+		n += proto.SizeVarint(19<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Event_ArticleScheduled:
+		s := proto.Size(x.ArticleScheduled)
+		n += proto.SizeVarint(21<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// ArticleCreated is recorded when a new article is created.
+type ArticleCreated struct {
+	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+}
+
+func (m *ArticleCreated) Reset()                    { *m = ArticleCreated{} }
+func (m *ArticleCreated) String() string            { return proto.CompactTextString(m) }
+func (*ArticleCreated) ProtoMessage()               {}
+func (*ArticleCreated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ArticleCreated) GetArticle() *Article {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+// ArticleUpdated is recorded when the content of an article is modified.
+type ArticleUpdated struct {
+	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+}
+
+func (m *ArticleUpdated) Reset()                    { *m = ArticleUpdated{} }
+func (m *ArticleUpdated) String() string            { return proto.CompactTextString(m) }
+func (*ArticleUpdated) ProtoMessage()               {}
+func (*ArticleUpdated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ArticleUpdated) GetArticle() *Article {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+// ArticleDrafted is recorded when an article becomes a draft.
+type ArticleDrafted struct {
+	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+}
+
+func (m *ArticleDrafted) Reset()                    { *m = ArticleDrafted{} }
+func (m *ArticleDrafted) String() string            { return proto.CompactTextString(m) }
+func (*ArticleDrafted) ProtoMessage()               {}
+func (*ArticleDrafted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *ArticleDrafted) GetArticle() *Article {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+// ArticlePublished is recorded when an article goes live.
+type ArticlePublished struct {
+	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+}
+
+func (m *ArticlePublished) Reset()                    { *m = ArticlePublished{} }
+func (m *ArticlePublished) String() string            { return proto.CompactTextString(m) }
+func (*ArticlePublished) ProtoMessage()               {}
+func (*ArticlePublished) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *ArticlePublished) GetArticle() *Article {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+// ArticleScheduled is recorded when an article is scheduled to be published at publish_at.
+type ArticleScheduled struct {
+	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+}
+
+func (m *ArticleScheduled) Reset()                    { *m = ArticleScheduled{} }
+func (m *ArticleScheduled) String() string            { return proto.CompactTextString(m) }
+func (*ArticleScheduled) ProtoMessage()               {}
+func (*ArticleScheduled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *ArticleScheduled) GetArticle() *Article {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+// ArticleRetracted is recorded when a published article is taken down.
+type ArticleRetracted struct {
+	Article *Article `protobuf:"bytes,1,opt,name=article" json:"article,omitempty"`
+}
+
+func (m *ArticleRetracted) Reset()                    { *m = ArticleRetracted{} }
+func (m *ArticleRetracted) String() string            { return proto.CompactTextString(m) }
+func (*ArticleRetracted) ProtoMessage()               {}
+func (*ArticleRetracted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ArticleRetracted) GetArticle() *Article {
+	if m != nil {
+		return m.Article
+	}
+	return nil
+}
+
+// ArticleRetitled is recorded when the title of an article changes.
+type ArticleRetitled struct {
+	Title         string `protobuf:"bytes,1,opt,name=title" json:"title,omitempty"`
+	PreviousTitle string `protobuf:"bytes,2,opt,name=previous_title,json=previousTitle" json:"previous_title,omitempty"`
+}
+
+func (m *ArticleRetitled) Reset()                    { *m = ArticleRetitled{} }
+func (m *ArticleRetitled) String() string            { return proto.CompactTextString(m) }
+func (*ArticleRetitled) ProtoMessage()               {}
+func (*ArticleRetitled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ArticleRetitled) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *ArticleRetitled) GetPreviousTitle() string {
+	if m != nil {
+		return m.PreviousTitle
+	}
+	return ""
+}
+
+// ArticleRecategorised is recorded when an article moves to another category.
+type ArticleRecategorised struct {
+	Category         string `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
+	PreviousCategory string `protobuf:"bytes,2,opt,name=previous_category,json=previousCategory" json:"previous_category,omitempty"`
+}
+
+func (m *ArticleRecategorised) Reset()                    { *m = ArticleRecategorised{} }
+func (m *ArticleRecategorised) String() string            { return proto.CompactTextString(m) }
+func (*ArticleRecategorised) ProtoMessage()               {}
+func (*ArticleRecategorised) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ArticleRecategorised) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *ArticleRecategorised) GetPreviousCategory() string {
+	if m != nil {
+		return m.PreviousCategory
+	}
+	return ""
+}
+
+// AuthorCreated is recorded when a new author is created.
+type AuthorCreated struct {
+	Author *Author `protobuf:"bytes,1,opt,name=author" json:"author,omitempty"`
+}
+
+func (m *AuthorCreated) Reset()                    { *m = AuthorCreated{} }
+func (m *AuthorCreated) String() string            { return proto.CompactTextString(m) }
+func (*AuthorCreated) ProtoMessage()               {}
+func (*AuthorCreated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *AuthorCreated) GetAuthor() *Author {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+// AuthorUpdated is recorded when the profile of an author is modified.
+type AuthorUpdated struct {
+	Author *Author `protobuf:"bytes,1,opt,name=author" json:"author,omitempty"`
+}
+
+func (m *AuthorUpdated) Reset()                    { *m = AuthorUpdated{} }
+func (m *AuthorUpdated) String() string            { return proto.CompactTextString(m) }
+func (*AuthorUpdated) ProtoMessage()               {}
+func (*AuthorUpdated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *AuthorUpdated) GetAuthor() *Author {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+// AuthorRenamed is recorded when the name of an author changes.
+// The articles of the author show the new name from then on.
+type AuthorRenamed struct {
+	Name         string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	PreviousName string `protobuf:"bytes,2,opt,name=previous_name,json=previousName" json:"previous_name,omitempty"`
+}
+
+func (m *AuthorRenamed) Reset()                    { *m = AuthorRenamed{} }
+func (m *AuthorRenamed) String() string            { return proto.CompactTextString(m) }
+func (*AuthorRenamed) ProtoMessage()               {}
+func (*AuthorRenamed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *AuthorRenamed) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AuthorRenamed) GetPreviousName() string {
+	if m != nil {
+		return m.PreviousName
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ArticleRequest)(nil), "publishing.ArticleRequest")
+	proto.RegisterType((*ArticleReply)(nil), "publishing.ArticleReply")
+	proto.RegisterType((*ArticlesReply)(nil), "publishing.ArticlesReply")
+	proto.RegisterType((*BatchGetRequest)(nil), "publishing.BatchGetRequest")
+	proto.RegisterType((*CreateArticleRequest)(nil), "publishing.CreateArticleRequest")
+	proto.RegisterType((*UpdateArticleRequest)(nil), "publishing.UpdateArticleRequest")
+	proto.RegisterType((*ListArticleRevisionsRequest)(nil), "publishing.ListArticleRevisionsRequest")
+	proto.RegisterType((*GetArticleRevisionRequest)(nil), "publishing.GetArticleRevisionRequest")
+	proto.RegisterType((*RevertArticleRequest)(nil), "publishing.RevertArticleRequest")
+	proto.RegisterType((*PublishArticleRequest)(nil), "publishing.PublishArticleRequest")
+	proto.RegisterType((*RetractArticleRequest)(nil), "publishing.RetractArticleRequest")
+	proto.RegisterType((*UnpublishArticleRequest)(nil), "publishing.UnpublishArticleRequest")
+	proto.RegisterType((*ArticleRevisionsReply)(nil), "publishing.ArticleRevisionsReply")
+	proto.RegisterType((*ArticleRevisionReply)(nil), "publishing.ArticleRevisionReply")
+	proto.RegisterType((*ArticleRevision)(nil), "publishing.ArticleRevision")
+	proto.RegisterType((*FieldChange)(nil), "publishing.FieldChange")
+	proto.RegisterType((*LatestArticlesRequest)(nil), "publishing.LatestArticlesRequest")
+	proto.RegisterType((*SubscribeEventsRequest)(nil), "publishing.SubscribeEventsRequest")
+	proto.RegisterType((*SearchArticlesRequest)(nil), "publishing.SearchArticlesRequest")
+	proto.RegisterType((*SearchArticlesReply)(nil), "publishing.SearchArticlesReply")
+	proto.RegisterType((*SearchHit)(nil), "publishing.SearchHit")
+	proto.RegisterType((*Snippet)(nil), "publishing.Snippet")
+	proto.RegisterType((*Article)(nil), "publishing.Article")
+	proto.RegisterType((*AuthorRequest)(nil), "publishing.AuthorRequest")
+	proto.RegisterType((*AuthorReply)(nil), "publishing.AuthorReply")
+	proto.RegisterType((*AuthorsReply)(nil), "publishing.AuthorsReply")
+	proto.RegisterType((*CreateAuthorRequest)(nil), "publishing.CreateAuthorRequest")
+	proto.RegisterType((*UpdateAuthorRequest)(nil), "publishing.UpdateAuthorRequest")
+	proto.RegisterType((*Author)(nil), "publishing.Author")
+	proto.RegisterType((*Event)(nil), "publishing.Event")
+	proto.RegisterType((*ArticleCreated)(nil), "publishing.ArticleCreated")
+	proto.RegisterType((*ArticleUpdated)(nil), "publishing.ArticleUpdated")
+	proto.RegisterType((*ArticleDrafted)(nil), "publishing.ArticleDrafted")
+	proto.RegisterType((*ArticlePublished)(nil), "publishing.ArticlePublished")
+	proto.RegisterType((*ArticleScheduled)(nil), "publishing.ArticleScheduled")
+	proto.RegisterType((*ArticleRetracted)(nil), "publishing.ArticleRetracted")
+	proto.RegisterType((*ArticleRetitled)(nil), "publishing.ArticleRetitled")
+	proto.RegisterType((*ArticleRecategorised)(nil), "publishing.ArticleRecategorised")
+	proto.RegisterType((*AuthorCreated)(nil), "publishing.AuthorCreated")
+	proto.RegisterType((*AuthorUpdated)(nil), "publishing.AuthorUpdated")
+	proto.RegisterType((*AuthorRenamed)(nil), "publishing.AuthorRenamed")
+	proto.RegisterEnum("publishing.ArticleStatus", ArticleStatus_name, ArticleStatus_value)
+	proto.RegisterEnum("publishing.SubscribeEventsRequest_Start", SubscribeEventsRequest_Start_name, SubscribeEventsRequest_Start_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Articles service
+
+type ArticlesClient interface {
+	// Article returns a single article by ID
+	Article(ctx context.Context, in *ArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
+	// CreateArticle creates an article
+	CreateArticle(ctx context.Context, in *CreateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
+	// UpdateArticle updates existing article
+	UpdateArticle(ctx context.Context, in *UpdateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
+	// LatestArticles queries for latest articles by the given params
+	LatestArticles(ctx context.Context, in *LatestArticlesRequest, opts ...grpc.CallOption) (*ArticlesReply, error)
+	// SubscribeEvents streams the events of articles and authors from the given position and keeps
+	// the stream open for events appended later on
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (Articles_SubscribeEventsClient, error)
+	// SearchArticles performs a full-text search of the articles
+	SearchArticles(ctx context.Context, in *SearchArticlesRequest, opts ...grpc.CallOption) (*SearchArticlesReply, error)
+	// BatchGetArticles returns many articles by ID at once
+	BatchGetArticles(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*ArticlesReply, error)
+	// ListArticleRevisions returns the revisions of an article, newest first
+	ListArticleRevisions(ctx context.Context, in *ListArticleRevisionsRequest, opts ...grpc.CallOption) (*ArticleRevisionsReply, error)
+	// GetArticleRevision returns a single revision of an article
+	GetArticleRevision(ctx context.Context, in *GetArticleRevisionRequest, opts ...grpc.CallOption) (*ArticleRevisionReply, error)
+	// RevertArticle creates a new revision of an article which restores the content of an older one
+	RevertArticle(ctx context.Context, in *RevertArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
+	// PublishArticle changes the status of an article to PUBLISHED
+	PublishArticle(ctx context.Context, in *PublishArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
+	// RetractArticle changes the status of a published article to RETRACTED
+	RetractArticle(ctx context.Context, in *RetractArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
+	// UnpublishArticle changes the status of a published article back to DRAFT
+	UnpublishArticle(ctx context.Context, in *UnpublishArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error)
+}
+
+type articlesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewArticlesClient(cc *grpc.ClientConn) ArticlesClient {
+	return &articlesClient{cc}
+}
+
+func (c *articlesClient) Article(ctx context.Context, in *ArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
+	out := new(ArticleReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/Article", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) CreateArticle(ctx context.Context, in *CreateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
+	out := new(ArticleReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/CreateArticle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) UpdateArticle(ctx context.Context, in *UpdateArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
+	out := new(ArticleReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/UpdateArticle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) LatestArticles(ctx context.Context, in *LatestArticlesRequest, opts ...grpc.CallOption) (*ArticlesReply, error) {
+	out := new(ArticlesReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/LatestArticles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (Articles_SubscribeEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Articles_serviceDesc.Streams[0], c.cc, "/publishing.Articles/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &articlesSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Articles_SubscribeEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type articlesSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *articlesSubscribeEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *articlesClient) SearchArticles(ctx context.Context, in *SearchArticlesRequest, opts ...grpc.CallOption) (*SearchArticlesReply, error) {
+	out := new(SearchArticlesReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/SearchArticles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) BatchGetArticles(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*ArticlesReply, error) {
+	out := new(ArticlesReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/BatchGetArticles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) ListArticleRevisions(ctx context.Context, in *ListArticleRevisionsRequest, opts ...grpc.CallOption) (*ArticleRevisionsReply, error) {
+	out := new(ArticleRevisionsReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/ListArticleRevisions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) GetArticleRevision(ctx context.Context, in *GetArticleRevisionRequest, opts ...grpc.CallOption) (*ArticleRevisionReply, error) {
+	out := new(ArticleRevisionReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/GetArticleRevision", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) RevertArticle(ctx context.Context, in *RevertArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
+	out := new(ArticleReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/RevertArticle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) PublishArticle(ctx context.Context, in *PublishArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
+	out := new(ArticleReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/PublishArticle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) RetractArticle(ctx context.Context, in *RetractArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
+	out := new(ArticleReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/RetractArticle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) UnpublishArticle(ctx context.Context, in *UnpublishArticleRequest, opts ...grpc.CallOption) (*ArticleReply, error) {
+	out := new(ArticleReply)
+	err := grpc.Invoke(ctx, "/publishing.Articles/UnpublishArticle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Articles service
+
+type ArticlesServer interface {
+	// Article returns a single article by ID
+	Article(context.Context, *ArticleRequest) (*ArticleReply, error)
+	// CreateArticle creates an article
+	CreateArticle(context.Context, *CreateArticleRequest) (*ArticleReply, error)
+	// UpdateArticle updates existing article
+	UpdateArticle(context.Context, *UpdateArticleRequest) (*ArticleReply, error)
+	// LatestArticles queries for latest articles by the given params
+	LatestArticles(context.Context, *LatestArticlesRequest) (*ArticlesReply, error)
+	// SubscribeEvents streams the events of articles and authors from the given position and keeps
+	// the stream open for events appended later on
+	SubscribeEvents(*SubscribeEventsRequest, Articles_SubscribeEventsServer) error
+	// SearchArticles performs a full-text search of the articles
+	SearchArticles(context.Context, *SearchArticlesRequest) (*SearchArticlesReply, error)
+	// BatchGetArticles returns many articles by ID at once
+	BatchGetArticles(context.Context, *BatchGetRequest) (*ArticlesReply, error)
+	// ListArticleRevisions returns the revisions of an article, newest first
+	ListArticleRevisions(context.Context, *ListArticleRevisionsRequest) (*ArticleRevisionsReply, error)
+	// GetArticleRevision returns a single revision of an article
+	GetArticleRevision(context.Context, *GetArticleRevisionRequest) (*ArticleRevisionReply, error)
+	// RevertArticle creates a new revision of an article which restores the content of an older one
+	RevertArticle(context.Context, *RevertArticleRequest) (*ArticleReply, error)
+	// PublishArticle changes the status of an article to PUBLISHED
+	PublishArticle(context.Context, *PublishArticleRequest) (*ArticleReply, error)
+	// RetractArticle changes the status of a published article to RETRACTED
+	RetractArticle(context.Context, *RetractArticleRequest) (*ArticleReply, error)
+	// UnpublishArticle changes the status of a published article back to DRAFT
+	UnpublishArticle(context.Context, *UnpublishArticleRequest) (*ArticleReply, error)
+}
+
+func RegisterArticlesServer(s *grpc.Server, srv ArticlesServer) {
+	s.RegisterService(&_Articles_serviceDesc, srv)
+}
+
+func _Articles_Article_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).Article(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/Article",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).Article(ctx, req.(*ArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_CreateArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).CreateArticle(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
@@ -423,6 +2192,171 @@ func _Articles_LatestArticles_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Articles_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArticlesServer).SubscribeEvents(m, &articlesSubscribeEventsServer{stream})
+}
+
+type Articles_SubscribeEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type articlesSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *articlesSubscribeEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Articles_SearchArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchArticlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).SearchArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/SearchArticles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).SearchArticles(ctx, req.(*SearchArticlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_BatchGetArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).BatchGetArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/BatchGetArticles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).BatchGetArticles(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_ListArticleRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArticleRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).ListArticleRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/ListArticleRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).ListArticleRevisions(ctx, req.(*ListArticleRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_GetArticleRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArticleRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).GetArticleRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/GetArticleRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).GetArticleRevision(ctx, req.(*GetArticleRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_RevertArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).RevertArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/RevertArticle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).RevertArticle(ctx, req.(*RevertArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_PublishArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).PublishArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/PublishArticle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).PublishArticle(ctx, req.(*PublishArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_RetractArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).RetractArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/RetractArticle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).RetractArticle(ctx, req.(*RetractArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_UnpublishArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).UnpublishArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Articles/UnpublishArticle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).UnpublishArticle(ctx, req.(*UnpublishArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Articles_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "publishing.Articles",
 	HandlerType: (*ArticlesServer)(nil),
@@ -443,6 +2377,215 @@ var _Articles_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LatestArticles",
 			Handler:    _Articles_LatestArticles_Handler,
 		},
+		{
+			MethodName: "SearchArticles",
+			Handler:    _Articles_SearchArticles_Handler,
+		},
+		{
+			MethodName: "BatchGetArticles",
+			Handler:    _Articles_BatchGetArticles_Handler,
+		},
+		{
+			MethodName: "ListArticleRevisions",
+			Handler:    _Articles_ListArticleRevisions_Handler,
+		},
+		{
+			MethodName: "GetArticleRevision",
+			Handler:    _Articles_GetArticleRevision_Handler,
+		},
+		{
+			MethodName: "RevertArticle",
+			Handler:    _Articles_RevertArticle_Handler,
+		},
+		{
+			MethodName: "PublishArticle",
+			Handler:    _Articles_PublishArticle_Handler,
+		},
+		{
+			MethodName: "RetractArticle",
+			Handler:    _Articles_RetractArticle_Handler,
+		},
+		{
+			MethodName: "UnpublishArticle",
+			Handler:    _Articles_UnpublishArticle_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _Articles_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "publishing.proto",
+}
+
+// Client API for Authors service
+
+type AuthorsClient interface {
+	// Author returns a single author by ID
+	Author(ctx context.Context, in *AuthorRequest, opts ...grpc.CallOption) (*AuthorReply, error)
+	// CreateAuthor creates an author
+	CreateAuthor(ctx context.Context, in *CreateAuthorRequest, opts ...grpc.CallOption) (*AuthorReply, error)
+	// UpdateAuthor updates existing author
+	UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*AuthorReply, error)
+	// BatchGetAuthors returns many authors by ID at once
+	BatchGetAuthors(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*AuthorsReply, error)
+}
+
+type authorsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuthorsClient(cc *grpc.ClientConn) AuthorsClient {
+	return &authorsClient{cc}
+}
+
+func (c *authorsClient) Author(ctx context.Context, in *AuthorRequest, opts ...grpc.CallOption) (*AuthorReply, error) {
+	out := new(AuthorReply)
+	err := grpc.Invoke(ctx, "/publishing.Authors/Author", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorsClient) CreateAuthor(ctx context.Context, in *CreateAuthorRequest, opts ...grpc.CallOption) (*AuthorReply, error) {
+	out := new(AuthorReply)
+	err := grpc.Invoke(ctx, "/publishing.Authors/CreateAuthor", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorsClient) UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*AuthorReply, error) {
+	out := new(AuthorReply)
+	err := grpc.Invoke(ctx, "/publishing.Authors/UpdateAuthor", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorsClient) BatchGetAuthors(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*AuthorsReply, error) {
+	out := new(AuthorsReply)
+	err := grpc.Invoke(ctx, "/publishing.Authors/BatchGetAuthors", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Authors service
+
+type AuthorsServer interface {
+	// Author returns a single author by ID
+	Author(context.Context, *AuthorRequest) (*AuthorReply, error)
+	// CreateAuthor creates an author
+	CreateAuthor(context.Context, *CreateAuthorRequest) (*AuthorReply, error)
+	// UpdateAuthor updates existing author
+	UpdateAuthor(context.Context, *UpdateAuthorRequest) (*AuthorReply, error)
+	// BatchGetAuthors returns many authors by ID at once
+	BatchGetAuthors(context.Context, *BatchGetRequest) (*AuthorsReply, error)
+}
+
+func RegisterAuthorsServer(s *grpc.Server, srv AuthorsServer) {
+	s.RegisterService(&_Authors_serviceDesc, srv)
+}
+
+func _Authors_Author_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorsServer).Author(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Authors/Author",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorsServer).Author(ctx, req.(*AuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authors_CreateAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorsServer).CreateAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Authors/CreateAuthor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorsServer).CreateAuthor(ctx, req.(*CreateAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authors_UpdateAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorsServer).UpdateAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Authors/UpdateAuthor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorsServer).UpdateAuthor(ctx, req.(*UpdateAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authors_BatchGetAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorsServer).BatchGetAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/publishing.Authors/BatchGetAuthors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorsServer).BatchGetAuthors(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Authors_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "publishing.Authors",
+	HandlerType: (*AuthorsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Author",
+			Handler:    _Authors_Author_Handler,
+		},
+		{
+			MethodName: "CreateAuthor",
+			Handler:    _Authors_CreateAuthor_Handler,
+		},
+		{
+			MethodName: "UpdateAuthor",
+			Handler:    _Authors_UpdateAuthor_Handler,
+		},
+		{
+			MethodName: "BatchGetAuthors",
+			Handler:    _Authors_BatchGetAuthors_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "publishing.proto",
@@ -451,36 +2594,131 @@ var _Articles_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("publishing.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 489 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0x51, 0x8f, 0x93, 0x40,
-	0x10, 0xc7, 0x0f, 0x7a, 0x2d, 0x30, 0xb5, 0x0d, 0x19, 0x6b, 0x82, 0xf8, 0x70, 0xc8, 0xd3, 0xc5,
-	0x44, 0x1a, 0xab, 0xf1, 0xcd, 0xc4, 0x7a, 0xc5, 0x78, 0xf1, 0xac, 0x17, 0xae, 0x8d, 0x8f, 0x86,
-	0x96, 0xbd, 0xde, 0x26, 0x50, 0x90, 0x5d, 0x1e, 0x9a, 0xf8, 0xd5, 0xf4, 0xb3, 0x99, 0xdb, 0x05,
-	0x5a, 0x2e, 0xe8, 0x69, 0x7c, 0x63, 0x66, 0xfe, 0xfb, 0xdb, 0x61, 0xe6, 0xbf, 0x60, 0x66, 0xc5,
-	0x2a, 0xa6, 0xec, 0x86, 0x6e, 0x37, 0x5e, 0x96, 0xa7, 0x3c, 0x45, 0xd8, 0x67, 0xec, 0x93, 0x4d,
-	0x9a, 0x6e, 0x62, 0x32, 0x16, 0x95, 0x55, 0x71, 0x3d, 0xe6, 0x34, 0x21, 0x8c, 0x87, 0x49, 0x26,
-	0xc5, 0xae, 0x03, 0xc3, 0x69, 0xce, 0xe9, 0x3a, 0x26, 0x01, 0xf9, 0x56, 0x10, 0xc6, 0x71, 0x08,
-	0x2a, 0x8d, 0x2c, 0xc5, 0x51, 0x4e, 0x07, 0x81, 0x4a, 0x23, 0xf7, 0x0d, 0x3c, 0xa8, 0x15, 0x59,
-	0xbc, 0xc3, 0xe7, 0xa0, 0x85, 0x32, 0x16, 0xa2, 0xfe, 0xe4, 0xa1, 0x77, 0xd0, 0x42, 0x25, 0xad,
-	0x34, 0xee, 0x5b, 0x18, 0x94, 0x39, 0x26, 0xcf, 0x8f, 0x41, 0x2f, 0x6b, 0xcc, 0x52, 0x9c, 0xce,
-	0xef, 0x00, 0xb5, 0xc8, 0xf5, 0x61, 0x74, 0x96, 0x93, 0x90, 0x93, 0x3b, 0x8d, 0xfe, 0x63, 0x23,
-	0x3e, 0x8c, 0x96, 0x59, 0xf4, 0xdf, 0x98, 0xef, 0xf0, 0xe8, 0x22, 0xe4, 0x84, 0xf1, 0xfd, 0x5f,
-	0x49, 0xce, 0x0b, 0xe8, 0x31, 0x1e, 0xf2, 0x82, 0x09, 0xcc, 0x70, 0xf2, 0xb8, 0x05, 0x73, 0x25,
-	0x04, 0x41, 0x29, 0xc4, 0x11, 0x74, 0xd7, 0x69, 0xb1, 0xe5, 0x96, 0x2a, 0xa6, 0x2d, 0x03, 0xb4,
-	0x41, 0x5f, 0x87, 0x9c, 0x6c, 0xd2, 0x7c, 0x67, 0x75, 0x1c, 0xe5, 0xd4, 0x08, 0xea, 0xd8, 0xfd,
-	0xa9, 0x82, 0x56, 0xb2, 0xee, 0x2e, 0xea, 0x96, 0xc6, 0x29, 0x8f, 0x89, 0xa0, 0x19, 0x81, 0x0c,
-	0x10, 0xe1, 0x78, 0x95, 0x46, 0x15, 0x49, 0x7c, 0x37, 0x6e, 0x38, 0x6e, 0xde, 0x80, 0x4f, 0xc0,
-	0x08, 0x0b, 0x7e, 0x93, 0xe6, 0x5f, 0x69, 0x64, 0x75, 0x05, 0x5c, 0x97, 0x89, 0xf3, 0x08, 0x4f,
-	0xa0, 0x5f, 0x16, 0xb7, 0x61, 0x42, 0xac, 0x9e, 0x38, 0x0b, 0x32, 0x35, 0x0f, 0x13, 0x82, 0xaf,
-	0x40, 0x5b, 0x8b, 0x5d, 0x45, 0x96, 0x26, 0x86, 0x69, 0x7b, 0xd2, 0x81, 0x5e, 0xe5, 0x40, 0x6f,
-	0x51, 0x39, 0x30, 0xa8, 0xa4, 0xf8, 0x1a, 0xf4, 0x24, 0x8d, 0xe8, 0x35, 0x25, 0x91, 0xa5, 0xdf,
-	0x7b, 0xac, 0xd6, 0x1e, 0x8c, 0xdc, 0xf8, 0xcb, 0x91, 0x3f, 0xf3, 0x6b, 0x3b, 0xca, 0x02, 0xf6,
-	0x41, 0x5b, 0xce, 0x3f, 0xce, 0x3f, 0x7f, 0x99, 0x9b, 0x47, 0x68, 0x40, 0x77, 0x16, 0x4c, 0xdf,
-	0x2f, 0x4c, 0x05, 0x07, 0x60, 0x5c, 0x2e, 0xdf, 0x5d, 0x9c, 0x5f, 0x7d, 0xf0, 0x67, 0xa6, 0x7a,
-	0x1b, 0x06, 0xfe, 0x22, 0x98, 0x9e, 0x2d, 0xfc, 0x99, 0xd9, 0x99, 0xfc, 0x50, 0x41, 0xaf, 0x0c,
-	0x80, 0xd3, 0xfd, 0x4e, 0xec, 0x36, 0xef, 0x48, 0x83, 0xd8, 0x56, 0x6b, 0x2d, 0x8b, 0x77, 0xee,
-	0x11, 0x7e, 0x82, 0x41, 0xc3, 0xe3, 0xe8, 0x1c, 0x8a, 0xdb, 0xec, 0x7f, 0x1f, 0xae, 0xe1, 0xf5,
-	0x26, 0xae, 0xed, 0x19, 0xfc, 0x11, 0x77, 0x09, 0xc3, 0xa6, 0xe7, 0xf1, 0xe9, 0xa1, 0xba, 0xf5,
-	0x3d, 0xd8, 0x6d, 0xcb, 0x60, 0x25, 0x71, 0xd5, 0x13, 0x7b, 0x7d, 0xf9, 0x2b, 0x00, 0x00, 0xff,
-	0xff, 0xa7, 0xdd, 0xce, 0xdd, 0xbe, 0x04, 0x00, 0x00,
+	// 2002 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xcf, 0x73, 0xdb, 0xc6,
+	0xf5, 0x17, 0x44, 0x8a, 0x24, 0x9e, 0x44, 0x0a, 0x5a, 0x51, 0x36, 0x4c, 0xe7, 0x3b, 0x92, 0xe1,
+	0xf1, 0x37, 0x4e, 0x52, 0xcb, 0x8d, 0xdb, 0x69, 0xeb, 0x49, 0x93, 0x0c, 0x25, 0xd1, 0x96, 0x6a,
+	0x55, 0xd6, 0x80, 0x94, 0x73, 0x64, 0x57, 0xc0, 0x8a, 0xc2, 0x04, 0x22, 0x10, 0x60, 0xa1, 0x89,
+	0x8e, 0x4d, 0x3b, 0x9d, 0x5e, 0xfa, 0x4f, 0xf4, 0xd8, 0x99, 0xf6, 0xdc, 0xe9, 0xa1, 0x7f, 0x45,
+	0xff, 0xa0, 0xce, 0x62, 0x77, 0x81, 0x05, 0x09, 0x92, 0xa6, 0x7b, 0xc8, 0x8d, 0xfb, 0xf6, 0xed,
+	0x07, 0x6f, 0xdf, 0xaf, 0x7d, 0xef, 0x11, 0x8c, 0x30, 0xb9, 0xf4, 0xbd, 0xf8, 0xda, 0x1b, 0x8f,
+	0xf6, 0xc3, 0x28, 0xa0, 0x01, 0x82, 0x9c, 0xd2, 0xd9, 0x1d, 0x05, 0xc1, 0xc8, 0x27, 0xcf, 0xd3,
+	0x9d, 0xcb, 0xe4, 0xea, 0x39, 0xf5, 0x6e, 0x48, 0x4c, 0xf1, 0x4d, 0xc8, 0x99, 0xad, 0x3d, 0x68,
+	0x75, 0x23, 0xea, 0x39, 0x3e, 0xb1, 0xc9, 0x77, 0x09, 0x89, 0x29, 0x6a, 0xc1, 0xaa, 0xe7, 0x9a,
+	0xda, 0x9e, 0xf6, 0xb4, 0x69, 0xaf, 0x7a, 0xae, 0xf5, 0x25, 0x6c, 0x64, 0x1c, 0xa1, 0x7f, 0x87,
+	0x9e, 0x41, 0x1d, 0xf3, 0x75, 0xca, 0xb4, 0xfe, 0x62, 0x7b, 0x5f, 0x11, 0x41, 0xb2, 0x4a, 0x1e,
+	0xeb, 0x07, 0x0d, 0x9a, 0x82, 0x18, 0x73, 0x80, 0xe7, 0xd0, 0x10, 0x9b, 0xb1, 0xa9, 0xed, 0x55,
+	0x66, 0x21, 0x64, 0x4c, 0xe8, 0xff, 0x61, 0x73, 0x4c, 0xbe, 0xa7, 0xc3, 0x10, 0x8f, 0xc8, 0x90,
+	0x06, 0xdf, 0x92, 0xb1, 0xb9, 0xba, 0xa7, 0x3d, 0xd5, 0xed, 0x26, 0x23, 0x9f, 0xe3, 0x11, 0x19,
+	0x30, 0x22, 0x32, 0xa1, 0xee, 0x24, 0x51, 0x1c, 0x44, 0xb1, 0x59, 0xd9, 0xab, 0x3c, 0xd5, 0x6d,
+	0xb9, 0xb4, 0x1e, 0xc3, 0xe6, 0x01, 0xa6, 0xce, 0xf5, 0x6b, 0x42, 0xe5, 0x35, 0x0d, 0xa8, 0x78,
+	0x2e, 0x17, 0xa0, 0x69, 0xb3, 0x9f, 0xd6, 0x9f, 0x34, 0x68, 0x1f, 0x46, 0x04, 0x53, 0x32, 0xa1,
+	0x91, 0xe5, 0x6e, 0x8c, 0xee, 0x41, 0x8d, 0xb8, 0x1e, 0x0d, 0x22, 0x21, 0xa5, 0x58, 0xa1, 0x8f,
+	0x61, 0xd3, 0x73, 0xc9, 0x4d, 0x18, 0x50, 0x32, 0x76, 0xee, 0x86, 0xdf, 0x92, 0x3b, 0xb3, 0x92,
+	0x32, 0xb4, 0x14, 0xf2, 0x1b, 0x72, 0x67, 0xfd, 0x59, 0x83, 0xf6, 0x45, 0xe8, 0xfe, 0xcf, 0x82,
+	0x7c, 0x02, 0x06, 0xf9, 0x3e, 0x24, 0x0e, 0x25, 0xee, 0xf0, 0x96, 0x44, 0xb1, 0x17, 0x70, 0xc5,
+	0x35, 0xed, 0x4d, 0x49, 0x7f, 0xc7, 0xc9, 0x8a, 0xcc, 0x15, 0x55, 0x66, 0xeb, 0x19, 0x3c, 0x3c,
+	0xf5, 0x62, 0x9a, 0xc9, 0x71, 0xeb, 0x31, 0xf6, 0x78, 0x96, 0xaf, 0xf4, 0xe0, 0xc1, 0x6b, 0x32,
+	0xc9, 0x3d, 0x83, 0x99, 0x99, 0xab, 0x28, 0x95, 0x5c, 0x5a, 0x7f, 0xd0, 0xa0, 0x6d, 0x93, 0x5b,
+	0x12, 0xd1, 0xf9, 0xbe, 0x39, 0x1b, 0xa2, 0xf4, 0xee, 0x95, 0x45, 0x77, 0xaf, 0x16, 0xee, 0xfe,
+	0x83, 0x06, 0x3b, 0xe7, 0x5c, 0xbd, 0x0b, 0xc4, 0x58, 0x4e, 0xd1, 0x11, 0xc1, 0xb1, 0x90, 0x46,
+	0xb7, 0xc5, 0x6a, 0xae, 0x10, 0x36, 0xa1, 0x11, 0x76, 0xe8, 0x8f, 0x27, 0xc4, 0x1f, 0x35, 0xb8,
+	0x7f, 0x31, 0x0e, 0x7f, 0x6c, 0x5d, 0xd8, 0xb0, 0x33, 0xed, 0x88, 0x2c, 0xa3, 0xbc, 0x04, 0x3d,
+	0x92, 0x14, 0x91, 0x52, 0x1e, 0x96, 0x45, 0x86, 0x74, 0xc8, 0x9c, 0xdb, 0x7a, 0x0b, 0xed, 0xc9,
+	0xdd, 0x14, 0xf2, 0x97, 0xd0, 0x90, 0x4c, 0x22, 0xd6, 0xe6, 0x22, 0x66, 0xcc, 0xd6, 0x3f, 0x56,
+	0x61, 0x73, 0x62, 0x57, 0xd1, 0x91, 0xbe, 0xc0, 0x6d, 0x95, 0x08, 0xaf, 0x2c, 0x95, 0x6a, 0x0a,
+	0x9a, 0x42, 0x3f, 0x87, 0xba, 0x93, 0x66, 0x32, 0xd7, 0x5c, 0x4b, 0x61, 0x3a, 0xfb, 0xfc, 0x21,
+	0xd8, 0x97, 0x0f, 0xc1, 0xfe, 0x40, 0x3e, 0x04, 0xb6, 0x64, 0x45, 0x9f, 0x43, 0xdd, 0xb9, 0xc6,
+	0xe3, 0x11, 0x89, 0xcd, 0x5a, 0xaa, 0xc4, 0xfb, 0xea, 0xc7, 0x5f, 0x79, 0xc4, 0x77, 0x0f, 0xd3,
+	0x7d, 0x5b, 0xf2, 0x31, 0x6b, 0x47, 0x69, 0xa0, 0x2a, 0xd6, 0xae, 0x73, 0x6b, 0x4b, 0xfa, 0xb4,
+	0xb5, 0x1b, 0xaa, 0xb5, 0xad, 0xdf, 0xc1, 0xba, 0x02, 0x8d, 0xda, 0xb0, 0x76, 0xc5, 0x96, 0x42,
+	0x5d, 0x7c, 0x81, 0x9e, 0x40, 0x2b, 0x64, 0x2a, 0x0e, 0x92, 0x78, 0x78, 0x8b, 0xfd, 0x84, 0xc8,
+	0x17, 0x40, 0x52, 0xdf, 0x31, 0x22, 0x3b, 0xcc, 0x77, 0xb9, 0x43, 0xf1, 0x85, 0xf5, 0x4f, 0x0d,
+	0x76, 0x4e, 0x31, 0x25, 0x59, 0x1e, 0xcb, 0xf2, 0xd7, 0xe7, 0x50, 0x8b, 0x29, 0xa6, 0x49, 0x9c,
+	0x7e, 0xad, 0xf5, 0xe2, 0x41, 0x89, 0xb6, 0xfb, 0x29, 0x83, 0x2d, 0x18, 0xd9, 0x27, 0x9c, 0x20,
+	0x19, 0x53, 0x61, 0x39, 0xbe, 0x40, 0x1d, 0x68, 0x38, 0x98, 0x92, 0x51, 0x10, 0xc9, 0xa4, 0x9e,
+	0xad, 0xd1, 0xff, 0x01, 0x28, 0x2f, 0x17, 0x37, 0x94, 0x1e, 0x66, 0xaf, 0xd6, 0x43, 0xd0, 0x71,
+	0x42, 0xaf, 0x83, 0x68, 0xe8, 0x71, 0x6b, 0x35, 0xed, 0x06, 0x27, 0x9c, 0xb8, 0xd6, 0xbf, 0x34,
+	0xb8, 0xd7, 0x4f, 0x2e, 0x63, 0x27, 0xf2, 0x2e, 0x49, 0xef, 0x96, 0x8c, 0x69, 0x26, 0xfb, 0x57,
+	0xb0, 0x16, 0x53, 0x1c, 0x51, 0x21, 0xfa, 0x53, 0x55, 0xf4, 0xf2, 0x23, 0xfb, 0x7d, 0xc6, 0x6f,
+	0xf3, 0x63, 0x4c, 0xe4, 0x30, 0x88, 0x3d, 0x2a, 0xbd, 0xb0, 0x6a, 0x67, 0x6b, 0xb4, 0x03, 0x35,
+	0x3f, 0x18, 0x31, 0x81, 0x84, 0x22, 0xfd, 0x60, 0x74, 0xe2, 0x5a, 0xcf, 0x60, 0x2d, 0x85, 0x40,
+	0x4d, 0xd0, 0x0f, 0x7a, 0xaf, 0x4f, 0xce, 0xce, 0x4e, 0xce, 0x5e, 0x1b, 0x2b, 0x08, 0xa0, 0x76,
+	0xda, 0x1d, 0xf4, 0xfa, 0x03, 0x43, 0x43, 0x3a, 0xac, 0x75, 0x5f, 0x0d, 0x7a, 0xb6, 0xb1, 0x6a,
+	0xfd, 0x4d, 0x83, 0x9d, 0x3e, 0xc1, 0x91, 0x73, 0x3d, 0xa9, 0xf7, 0x36, 0xac, 0x7d, 0x97, 0x90,
+	0xe8, 0x4e, 0x1a, 0x39, 0x5d, 0x14, 0x94, 0xb8, 0x3a, 0xa1, 0xc4, 0xdc, 0x52, 0x95, 0xa5, 0x2d,
+	0x55, 0x55, 0x2d, 0x75, 0x0f, 0x6a, 0xc1, 0xd5, 0x55, 0x4c, 0xa8, 0xd0, 0xb5, 0x58, 0x59, 0xef,
+	0x60, 0x7b, 0x52, 0x56, 0x96, 0x07, 0x3e, 0x81, 0xea, 0xb5, 0x47, 0x65, 0x56, 0xd9, 0x29, 0x28,
+	0x39, 0x65, 0x3f, 0xf6, 0xa8, 0x9d, 0xb2, 0xb0, 0xef, 0xd1, 0x80, 0x62, 0x5f, 0x68, 0x93, 0x2f,
+	0xac, 0xdf, 0x6b, 0xa0, 0x67, 0x9c, 0xcb, 0xbe, 0xe0, 0x6d, 0x58, 0x8b, 0x9d, 0x20, 0xe2, 0xde,
+	0xae, 0xd9, 0x7c, 0xc1, 0x0a, 0xa8, 0x78, 0xec, 0x85, 0x21, 0xa1, 0xbc, 0xd0, 0x99, 0x40, 0xe9,
+	0xf3, 0x3d, 0x3b, 0x63, 0xb2, 0xbe, 0x84, 0xba, 0x20, 0xce, 0x08, 0xaf, 0x8f, 0x40, 0xbf, 0x8a,
+	0xf0, 0xe8, 0x86, 0x39, 0x8b, 0xb9, 0x9a, 0xd6, 0x4e, 0x39, 0xc1, 0xfa, 0x6b, 0x15, 0xea, 0x42,
+	0xb4, 0xa9, 0x74, 0xcf, 0x2e, 0xed, 0x51, 0x5f, 0xc6, 0x23, 0x5f, 0x20, 0x04, 0xd5, 0xcb, 0xc0,
+	0x95, 0xa1, 0x90, 0xfe, 0x2e, 0x58, 0xb7, 0x3a, 0x61, 0xdd, 0x79, 0x31, 0x80, 0x76, 0x61, 0x5d,
+	0x6c, 0x8e, 0xf1, 0x0d, 0x31, 0x6b, 0xe9, 0x59, 0xe0, 0xa4, 0x33, 0x7c, 0x43, 0xd4, 0x6c, 0x57,
+	0x7f, 0xff, 0x6c, 0xf7, 0x0b, 0x68, 0xdc, 0x04, 0xae, 0x77, 0xe5, 0x11, 0xd7, 0x6c, 0x2c, 0x3c,
+	0x96, 0xf1, 0x2a, 0x9e, 0xa8, 0xbf, 0xaf, 0x27, 0x2a, 0xf9, 0x1e, 0x8a, 0xf9, 0xfe, 0x57, 0xa0,
+	0x8b, 0xd3, 0xc4, 0x35, 0xd7, 0x17, 0x4a, 0x91, 0x33, 0x33, 0x15, 0xc7, 0x7e, 0x32, 0x32, 0x37,
+	0xb8, 0x8a, 0xd9, 0x6f, 0xf4, 0x12, 0x64, 0xed, 0x3f, 0xc4, 0xd4, 0x6c, 0xbe, 0x37, 0x5c, 0x97,
+	0xa2, 0xaf, 0xa1, 0x49, 0x6e, 0x2e, 0x71, 0x34, 0x0a, 0x86, 0xc9, 0x98, 0x7a, 0xbe, 0xd9, 0x5a,
+	0x78, 0x7a, 0x43, 0x1c, 0xb8, 0x60, 0xfc, 0xd6, 0x2e, 0x34, 0xbb, 0xa9, 0x49, 0x66, 0xd5, 0x86,
+	0x2f, 0x61, 0x5d, 0x32, 0xb0, 0xc0, 0xfa, 0x14, 0x6a, 0xdc, 0x84, 0x22, 0x10, 0x50, 0x41, 0x8d,
+	0x9c, 0x51, 0x70, 0x58, 0xbf, 0x86, 0x0d, 0x4e, 0x11, 0x41, 0xf9, 0x13, 0xa8, 0xf3, 0x1d, 0x19,
+	0x97, 0x65, 0x87, 0x25, 0x8b, 0xd5, 0x85, 0x6d, 0x51, 0xd6, 0x17, 0xe4, 0x5b, 0x46, 0x00, 0x1f,
+	0xb6, 0x45, 0x41, 0xfe, 0xa1, 0x10, 0x4b, 0xd4, 0x45, 0xd6, 0x5f, 0x34, 0xa8, 0xf1, 0xd3, 0x53,
+	0xe1, 0x86, 0xa0, 0x9a, 0x06, 0x01, 0x8f, 0xb6, 0xf4, 0x37, 0xeb, 0x64, 0x2e, 0xbd, 0x40, 0xc4,
+	0x1a, 0xfb, 0xc9, 0x82, 0x92, 0xdc, 0x60, 0xcf, 0x17, 0x71, 0xc6, 0x17, 0xec, 0x1d, 0xc2, 0xb7,
+	0x98, 0xe2, 0x68, 0x98, 0x44, 0x7e, 0x1a, 0x65, 0xba, 0xad, 0x73, 0xca, 0x45, 0xe4, 0xab, 0x4e,
+	0x5a, 0x2b, 0x96, 0xe3, 0xff, 0xd1, 0x61, 0x2d, 0x7d, 0x48, 0xa6, 0x0a, 0x99, 0x47, 0xb0, 0x81,
+	0x47, 0xa3, 0x88, 0x8c, 0x30, 0x25, 0x2c, 0x74, 0xf9, 0x85, 0xd6, 0x33, 0xda, 0x89, 0x8b, 0x3e,
+	0x83, 0xad, 0x9c, 0xa5, 0x58, 0x89, 0x1b, 0xd9, 0x86, 0xac, 0x11, 0x10, 0x54, 0xe9, 0x5d, 0x48,
+	0x84, 0xdc, 0xe9, 0x6f, 0xf4, 0x05, 0xac, 0x07, 0x8e, 0x93, 0x44, 0x11, 0x71, 0x99, 0x57, 0x2f,
+	0xae, 0x67, 0x40, 0xb2, 0x77, 0x29, 0x13, 0xd0, 0xc1, 0x49, 0x8c, 0xd9, 0xab, 0xc6, 0x04, 0xe4,
+	0xc9, 0x63, 0x3d, 0xa3, 0x9d, 0xa4, 0xa5, 0x85, 0x13, 0x44, 0x11, 0xf1, 0x33, 0xa6, 0x3a, 0x2f,
+	0x2d, 0x14, 0xea, 0x89, 0x5b, 0x78, 0x2e, 0x1b, 0x13, 0xcf, 0xe5, 0x13, 0x68, 0xe5, 0x77, 0x4c,
+	0x2f, 0xa0, 0x73, 0x88, 0x8c, 0x3a, 0xb8, 0x0b, 0xd5, 0x6a, 0xad, 0x5d, 0xa8, 0xd6, 0xf2, 0xca,
+	0xe8, 0x5e, 0xa1, 0x0e, 0x2e, 0x69, 0x18, 0xef, 0x97, 0x35, 0x8c, 0xa8, 0x07, 0x9b, 0xe2, 0xc5,
+	0x18, 0xca, 0x44, 0x08, 0x42, 0x4d, 0xd3, 0xb9, 0x89, 0xc7, 0x82, 0x7b, 0xbc, 0x62, 0xb7, 0x70,
+	0x81, 0xa2, 0xc2, 0x24, 0xa9, 0xb7, 0xe7, 0x29, 0x69, 0x1a, 0x86, 0xc7, 0x83, 0x0a, 0x23, 0x28,
+	0x2a, 0x8c, 0x1b, 0xe1, 0x2b, 0x06, 0xb3, 0x31, 0x13, 0xe6, 0x88, 0x73, 0x28, 0x30, 0x82, 0x82,
+	0xde, 0xc0, 0x96, 0x84, 0xc9, 0x53, 0x24, 0xcf, 0x69, 0x1f, 0x95, 0x00, 0x9d, 0x4b, 0x9e, 0xe3,
+	0x15, 0xdb, 0xc0, 0x13, 0x34, 0x15, 0x2c, 0xe2, 0xdd, 0x14, 0x71, 0xcd, 0xd6, 0x4c, 0x30, 0x5b,
+	0xf2, 0x28, 0x60, 0x19, 0x0d, 0x1d, 0x83, 0xa1, 0x80, 0xb1, 0x07, 0xcf, 0x35, 0x37, 0xe7, 0xf4,
+	0x08, 0x9c, 0xe5, 0x78, 0xc5, 0xde, 0xc4, 0x45, 0x12, 0xfa, 0x06, 0x76, 0x72, 0x24, 0xf1, 0x1a,
+	0x7a, 0x31, 0x71, 0x4d, 0x23, 0x85, 0xdb, 0x2b, 0x85, 0x53, 0xf8, 0x8e, 0x57, 0xec, 0x36, 0x2e,
+	0xa1, 0xa3, 0x03, 0x68, 0x89, 0x37, 0x53, 0x3a, 0xc4, 0x56, 0x8a, 0xf8, 0x60, 0x3a, 0x43, 0xe5,
+	0xfe, 0xd0, 0xc4, 0x2a, 0x41, 0xc1, 0x90, 0xde, 0x80, 0x66, 0x61, 0xe4, 0xce, 0xd0, 0xc4, 0x2a,
+	0x41, 0xc1, 0x88, 0x08, 0x4b, 0x56, 0xae, 0xb9, 0x3d, 0x0b, 0xc3, 0xe6, 0x0c, 0x39, 0x86, 0x20,
+	0xa8, 0xb6, 0x8b, 0x9d, 0x6b, 0xe2, 0x26, 0x4c, 0xdf, 0x3b, 0x33, 0x6d, 0xd7, 0x97, 0x3c, 0x8a,
+	0xed, 0x32, 0xda, 0x81, 0x0e, 0xf5, 0x10, 0xdf, 0xf9, 0x01, 0x76, 0xad, 0xaf, 0xb3, 0xd1, 0x97,
+	0xbc, 0xf1, 0x92, 0xa3, 0xad, 0x1c, 0x40, 0x5e, 0xf7, 0x83, 0x01, 0xa4, 0xd3, 0x2f, 0x09, 0xd0,
+	0x05, 0x63, 0xd2, 0xfd, 0x3f, 0x1c, 0x22, 0x53, 0xd2, 0x87, 0x43, 0xe4, 0x31, 0xb2, 0x24, 0xc4,
+	0x99, 0xd2, 0x34, 0x8b, 0xd8, 0xc8, 0x2a, 0x4b, 0x4d, 0xad, 0x2c, 0xd5, 0x46, 0x50, 0x2d, 0x3c,
+	0xb3, 0x46, 0x70, 0xc0, 0x88, 0xd6, 0x50, 0x69, 0xeb, 0xd5, 0xb8, 0x50, 0x8b, 0x50, 0x6d, 0xa2,
+	0x08, 0xfd, 0x0c, 0xb6, 0x32, 0xe8, 0x89, 0x3e, 0xc4, 0x90, 0x1b, 0x87, 0x82, 0x6e, 0x7d, 0x21,
+	0xcb, 0x1d, 0xe9, 0x3b, 0xcb, 0x94, 0x13, 0xd9, 0x61, 0xe9, 0x37, 0xcb, 0x1c, 0x3e, 0xce, 0x0b,
+	0x2d, 0x1e, 0x1f, 0xb2, 0x26, 0xd0, 0x94, 0x9a, 0xe0, 0x31, 0x64, 0x0a, 0x19, 0x2a, 0x05, 0xc3,
+	0x86, 0x24, 0xb2, 0xba, 0xf9, 0xd3, 0x01, 0x34, 0x0b, 0xf5, 0x2a, 0x5a, 0x87, 0xfa, 0xc5, 0xd9,
+	0x9b, 0xb3, 0xb7, 0xdf, 0x9c, 0x19, 0x2b, 0xac, 0x91, 0x3b, 0xb2, 0xbb, 0xaf, 0x58, 0x4f, 0xd7,
+	0x04, 0xfd, 0xfc, 0xe2, 0xe0, 0xf4, 0xa4, 0x7f, 0xdc, 0x3b, 0x32, 0x56, 0xd9, 0xd2, 0xee, 0x0d,
+	0xec, 0xee, 0xe1, 0xa0, 0x77, 0x64, 0x54, 0xd8, 0xb2, 0x7f, 0x78, 0xdc, 0x3b, 0xba, 0x38, 0xed,
+	0x1d, 0x19, 0xd5, 0x17, 0xff, 0x6e, 0x40, 0xa3, 0x2b, 0x47, 0xb7, 0xdd, 0xbc, 0x73, 0xe8, 0x94,
+	0x26, 0xb3, 0xb4, 0x90, 0xea, 0x98, 0xa5, 0x7b, 0xa1, 0x7f, 0x67, 0xad, 0xa0, 0xdf, 0x42, 0xb3,
+	0x30, 0x95, 0x45, 0x85, 0xac, 0x58, 0x36, 0xb0, 0x5d, 0x04, 0x57, 0x98, 0xad, 0x16, 0xe1, 0xca,
+	0xc6, 0xae, 0x73, 0xe1, 0xce, 0xa1, 0x55, 0x1c, 0x2d, 0xa0, 0x47, 0x2a, 0x77, 0xe9, 0xd8, 0xa1,
+	0x53, 0xd6, 0x32, 0xc4, 0x12, 0xf1, 0x14, 0x36, 0x27, 0xda, 0x77, 0x64, 0x2d, 0xee, 0xed, 0x3b,
+	0x5b, 0x2a, 0x4f, 0xba, 0x65, 0xad, 0xfc, 0x54, 0x43, 0xef, 0xa0, 0x55, 0x6c, 0x6b, 0x8b, 0xf2,
+	0x95, 0xb6, 0xe7, 0x9d, 0xdd, 0x79, 0x2c, 0x52, 0x4a, 0x43, 0x4e, 0xd4, 0x33, 0xe4, 0xc2, 0xeb,
+	0x37, 0x31, 0x6f, 0x9f, 0x7f, 0x67, 0x17, 0xda, 0x65, 0x63, 0x66, 0xf4, 0x71, 0x41, 0x97, 0xb3,
+	0x07, 0xd1, 0x9d, 0x47, 0x73, 0x86, 0x73, 0xd9, 0x57, 0x86, 0x80, 0xa6, 0xa7, 0xd3, 0xe8, 0x89,
+	0x7a, 0x74, 0xe6, 0xf4, 0xba, 0xb3, 0x37, 0xe7, 0x0b, 0x8a, 0x6f, 0x15, 0xc6, 0xd6, 0x45, 0xdf,
+	0x2a, 0x9b, 0x68, 0xcf, 0xf5, 0xad, 0xb7, 0xd0, 0x2a, 0xce, 0x9f, 0x8b, 0xb6, 0x2b, 0x9d, 0x4d,
+	0x2f, 0x02, 0x2c, 0xce, 0x92, 0x8b, 0x80, 0xa5, 0x73, 0xe6, 0xb9, 0x80, 0x7d, 0x30, 0x26, 0xe7,
+	0xc2, 0xe8, 0x71, 0x21, 0x9e, 0xca, 0xa7, 0xc6, 0xf3, 0x40, 0x5f, 0xfc, 0x7d, 0x15, 0xea, 0xa2,
+	0xdd, 0x43, 0x5f, 0x65, 0x9d, 0x50, 0x69, 0xc5, 0xc0, 0xc1, 0xee, 0x97, 0x6d, 0x71, 0x01, 0x7f,
+	0x03, 0x1b, 0x6a, 0xef, 0x87, 0x76, 0x4b, 0x72, 0xc7, 0x12, 0x58, 0x6a, 0x13, 0x58, 0xc4, 0x2a,
+	0x69, 0x0f, 0xe7, 0x63, 0x65, 0x7f, 0x48, 0xc9, 0xab, 0xce, 0x8d, 0x1e, 0x73, 0x1a, 0x4a, 0xba,
+	0xf5, 0x65, 0x2d, 0xed, 0x81, 0x7e, 0xf6, 0xdf, 0x01, 0x00, 0x35, 0xde, 0x29, 0x8f, 0x0a, 0x1c,
+	0x00, 0x00,
 }
@@ -0,0 +1,208 @@
+package search
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/blevesearch/bleve"
+	"github.com/blevesearch/bleve/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/analysis/lang/en"
+	"github.com/blevesearch/bleve/search/query"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/services/articles"
+)
+
+// eventsBatchSize is the maximum number of events indexed at once.
+const eventsBatchSize = 100
+
+// authorType is the aggregate type of the author events.
+var authorType = proto.MessageName(&pb.Author{})
+
+// document is the indexed representation of an article
+type document struct {
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	AuthorID   string `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	Category   string `json:"category"`
+	Status     string `json:"status"`
+}
+
+// Index is a Bleve full-text index of articles. It is a projection of the
+// article events and is rebuilt from the log every time it is created.
+type Index struct {
+	index bleve.Index
+}
+
+// NewIndex creates an empty in-memory index.
+func NewIndex() (*Index, error) {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = en.AnalyzerName
+	kw := bleve.NewTextFieldMapping()
+	kw.Analyzer = keyword.Name
+	kw.IncludeInAll = false
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("title", text)
+	doc.AddFieldMappingsAt("body", text)
+	doc.AddFieldMappingsAt("author_id", kw)
+	doc.AddFieldMappingsAt("author_name", text)
+	doc.AddFieldMappingsAt("category", kw)
+	doc.AddFieldMappingsAt("status", kw)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+
+	index, err := bleve.NewMemOnly(m)
+	if err != nil {
+		return nil, err
+	}
+	return &Index{index: index}, nil
+}
+
+// Run indexes the articles changed by the events in the log until ctx is done.
+func (i *Index) Run(ctx context.Context, log articles.EventLog, db articles.Factory) error {
+	var pos uint64
+	for {
+		events, err := log.Read(ctx, pos, eventsBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			if err := log.Wait(ctx, pos); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ids := make(map[uint32]bool)
+		for _, e := range events {
+			pos = e.Position
+			if e.AggregateType != authorType {
+				ids[e.AggregateId] = true
+				continue
+			}
+			// the articles show the current name of their author
+			if err := i.byAuthor(ctx, e.AggregateId, ids); err != nil {
+				return err
+			}
+		}
+		b, err := i.batch(ctx, db, ids)
+		if err != nil {
+			return err
+		}
+		if err := i.index.Batch(b); err != nil {
+			return err
+		}
+	}
+}
+
+// byAuthor adds the IDs of the indexed articles of the author to ids.
+func (i *Index) byAuthor(ctx context.Context, author uint32, ids map[uint32]bool) error {
+	req := bleve.NewSearchRequestOptions(term("author_id", docID(author)), eventsBatchSize, 0, false)
+	for {
+		res, err := i.index.SearchInContext(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, h := range res.Hits {
+			id, err := strconv.ParseUint(h.ID, 10, 32)
+			if err != nil {
+				return err
+			}
+			ids[uint32(id)] = true
+		}
+		req.From += len(res.Hits)
+		if len(res.Hits) == 0 || uint64(req.From) >= res.Total {
+			return nil
+		}
+	}
+}
+
+// batch indexes the current state of the articles.
+func (i *Index) batch(ctx context.Context, db articles.Factory, ids map[uint32]bool) (*bleve.Batch, error) {
+	b := i.index.NewBatch()
+	for id := range ids {
+		a, err := db.Get(ctx, id)
+		if err == articles.ErrArticleNotFound {
+			b.Delete(docID(id))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = b.Index(docID(id), document{
+			Title:      a.Title,
+			Body:       a.Body,
+			AuthorID:   docID(a.AuthorId),
+			AuthorName: a.AuthorName,
+			Category:   a.Category,
+			Status:     a.Status.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Search returns the articles matching the query string, ordered by relevance. A malformed query fails with *articles.QueryError.
+func (i *Index) Search(ctx context.Context, in *pb.SearchArticlesRequest) ([]articles.Hit, uint64, error) {
+	qs := bleve.NewQueryStringQuery(in.Query)
+	if _, err := qs.Parse(); err != nil {
+		return nil, 0, &articles.QueryError{Err: err}
+	}
+	q := []query.Query{qs}
+	if in.Category != "" {
+		q = append(q, term("category", in.Category))
+	}
+	if in.Status != pb.ArticleStatus_UNKNOWN {
+		q = append(q, term("status", in.Status.String()))
+	}
+
+	req := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(q...), int(in.Count), int(in.Offset), false)
+	req.Highlight = bleve.NewHighlightWithStyle("html")
+	req.Highlight.AddField("title")
+	req.Highlight.AddField("body")
+
+	res, err := i.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var hits []articles.Hit
+	for _, h := range res.Hits {
+		id, err := strconv.ParseUint(h.ID, 10, 32)
+		if err != nil {
+			return nil, 0, err
+		}
+		hit := articles.Hit{ID: uint32(id), Score: h.Score}
+		for _, field := range sortedKeys(h.Fragments) {
+			hit.Snippets = append(hit.Snippets, &pb.Snippet{Field: field, Fragments: h.Fragments[field]})
+		}
+		hits = append(hits, hit)
+	}
+	return hits, res.Total, nil
+}
+
+func term(field, value string) query.Query {
+	q := bleve.NewTermQuery(value)
+	q.SetField(field)
+	return q
+}
+
+func docID(id uint32) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func sortedKeys(m map[string][]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,32 @@
+package articles
+
+import (
+	"golang.org/x/net/context"
+)
+
+// Change describes who makes a change of an article and why. The server passes it
+// to the data store through the context, so the store can record it with the events.
+type Change struct {
+	// Editor is who makes the change, it is empty if unknown
+	Editor string
+	// Reason is why the change is made, it is empty if unknown
+	Reason string
+	// CausationID is the ID of the event which caused the change, e.g. the last event
+	// of the revision restored by a revert
+	CausationID string
+	// IdempotencyKey is the key of the request which creates an article, it is empty if unknown
+	IdempotencyKey string
+}
+
+type changeKey struct{}
+
+// WithChange returns a copy of ctx which carries c.
+func WithChange(ctx context.Context, c Change) context.Context {
+	return context.WithValue(ctx, changeKey{}, c)
+}
+
+// ChangeFrom returns the change carried by ctx, it is empty if there is none.
+func ChangeFrom(ctx context.Context) Change {
+	c, _ := ctx.Value(changeKey{}).(Change)
+	return c
+}
@@ -0,0 +1,101 @@
+package articles
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// Transition is a change of the status of an article. The transitions from
+// UNKNOWN are the statuses the articles can be created with.
+type Transition struct {
+	From pb.ArticleStatus
+	To   pb.ArticleStatus
+}
+
+// Policy is the editorial policy of the lifecycle of the articles.
+type Policy struct {
+	// Transitions are the allowed changes of the status.
+	Transitions []Transition
+	// Editable are the statuses in which the content of the articles can be changed.
+	Editable []pb.ArticleStatus
+}
+
+// DefaultPolicy lets the articles be published directly or on schedule and unpublished
+// back to drafts. The retracted articles are final and can be neither edited nor republished.
+var DefaultPolicy = Policy{
+	Transitions: []Transition{
+		{pb.ArticleStatus_UNKNOWN, pb.ArticleStatus_DRAFT},
+		{pb.ArticleStatus_UNKNOWN, pb.ArticleStatus_SCHEDULED},
+		{pb.ArticleStatus_UNKNOWN, pb.ArticleStatus_PUBLISHED},
+		{pb.ArticleStatus_DRAFT, pb.ArticleStatus_SCHEDULED},
+		{pb.ArticleStatus_DRAFT, pb.ArticleStatus_PUBLISHED},
+		{pb.ArticleStatus_SCHEDULED, pb.ArticleStatus_DRAFT},
+		{pb.ArticleStatus_SCHEDULED, pb.ArticleStatus_PUBLISHED},
+		{pb.ArticleStatus_PUBLISHED, pb.ArticleStatus_DRAFT},
+		{pb.ArticleStatus_PUBLISHED, pb.ArticleStatus_RETRACTED},
+	},
+	Editable: []pb.ArticleStatus{
+		pb.ArticleStatus_DRAFT,
+		pb.ArticleStatus_SCHEDULED,
+		pb.ArticleStatus_PUBLISHED,
+	},
+}
+
+// Check returns ErrInvalidTransition or ErrNotEditable if the policy doesn't allow
+// the article to change from cur to a. cur is nil when the article is created.
+func (p *Policy) Check(cur, a *pb.Article) error {
+	if cur == nil {
+		if !p.allows(pb.ArticleStatus_UNKNOWN, a.Status) {
+			return ErrInvalidTransition
+		}
+		return nil
+	}
+	if contentChanged(cur, a) && !p.editable(cur.Status) {
+		return ErrNotEditable
+	}
+	if cur.Status != a.Status && !p.allows(cur.Status, a.Status) {
+		return ErrInvalidTransition
+	}
+	return nil
+}
+
+func (p *Policy) allows(from, to pb.ArticleStatus) bool {
+	for _, t := range p.Transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) editable(s pb.ArticleStatus) bool {
+	for _, e := range p.Editable {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// contentChanged reports whether a differs from cur in anything the editors write.
+// The slug is kept when it is left empty.
+func contentChanged(cur, a *pb.Article) bool {
+	return cur.Title != a.Title || (a.Slug != "" && cur.Slug != a.Slug) || cur.Body != a.Body ||
+		cur.Category != a.Category || cur.AuthorId != a.AuthorId ||
+		!proto.Equal(cur.PublishAt, a.PublishAt) || !proto.Equal(cur.EmbargoUntil, a.EmbargoUntil)
+}
+
+// policyError describes why the policy doesn't allow the article to change from cur to a.
+func policyError(err error, cur, a *pb.Article) string {
+	if err == ErrNotEditable {
+		return fmt.Sprintf("%v: %s", err, cur.Status)
+	}
+	from := pb.ArticleStatus_UNKNOWN
+	if cur != nil {
+		from = cur.Status
+	}
+	return fmt.Sprintf("%v from %s to %s", err, from, a.Status)
+}
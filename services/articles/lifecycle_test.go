@@ -0,0 +1,47 @@
+package articles
+
+import (
+	"testing"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+func TestDefaultPolicyCheck(t *testing.T) {
+	article := func(s pb.ArticleStatus, title string) *pb.Article {
+		return &pb.Article{Id: 1, Title: title, Body: "body", Category: "business", Status: s}
+	}
+	const (
+		draft     = pb.ArticleStatus_DRAFT
+		published = pb.ArticleStatus_PUBLISHED
+		retracted = pb.ArticleStatus_RETRACTED
+		scheduled = pb.ArticleStatus_SCHEDULED
+	)
+
+	tests := []struct {
+		name string
+		cur  *pb.Article
+		a    *pb.Article
+		err  error
+	}{
+		{"create draft", nil, article(draft, "t"), nil},
+		{"create scheduled", nil, article(scheduled, "t"), nil},
+		{"create published", nil, article(published, "t"), nil},
+		{"create retracted", nil, article(retracted, "t"), ErrInvalidTransition},
+		{"publish draft", article(draft, "t"), article(published, "t"), nil},
+		{"schedule draft", article(draft, "t"), article(scheduled, "t"), nil},
+		{"publish scheduled", article(scheduled, "t"), article(published, "t"), nil},
+		{"unpublish", article(published, "t"), article(draft, "t"), nil},
+		{"retract", article(published, "t"), article(retracted, "t"), nil},
+		{"retract draft", article(draft, "t"), article(retracted, "t"), ErrInvalidTransition},
+		{"schedule published", article(published, "t"), article(scheduled, "t"), ErrInvalidTransition},
+		{"republish retracted", article(retracted, "t"), article(published, "t"), ErrInvalidTransition},
+		{"edit published", article(published, "t"), article(published, "t2"), nil},
+		{"edit retracted", article(retracted, "t"), article(retracted, "t2"), ErrNotEditable},
+		{"keep retracted", article(retracted, "t"), article(retracted, "t"), nil},
+	}
+	for _, tt := range tests {
+		if err := DefaultPolicy.Check(tt.cur, tt.a); err != tt.err {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
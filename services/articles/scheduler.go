@@ -0,0 +1,102 @@
+package articles
+
+import (
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+const (
+	// schedulerEditor is recorded as the editor of the articles published by the scheduler.
+	schedulerEditor = "scheduler"
+	// schedulerPageSize is the number of scheduled articles fetched at once.
+	schedulerPageSize = 100
+)
+
+// Scheduler publishes the SCHEDULED articles once their publish_at time has come.
+// The articles are published by updating them in the data store, so the usual
+// ArticlePublished events are recorded. The editorial policy applies to them like to any other change.
+type Scheduler struct {
+	db     Factory
+	clock  Clock
+	policy *Policy
+}
+
+// NewScheduler initialises a scheduler of the articles in db. The clock decides which articles are due.
+func NewScheduler(db Factory, clock Clock, policy *Policy) *Scheduler {
+	if db == nil {
+		panic("db cannot be <nil>.")
+	}
+	if clock == nil {
+		panic("clock cannot be <nil>.")
+	}
+	if policy == nil {
+		panic("policy cannot be <nil>.")
+	}
+	return &Scheduler{db: db, clock: clock, policy: policy}
+}
+
+// Run publishes the due articles every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if _, err := s.PublishDue(ctx); err != nil {
+			log.Printf("failed to publish the scheduled articles: %v\n", err)
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// PublishDue publishes the scheduled articles whose publish_at is not after the current time
+// and returns how many of them have been published. The articles which are modified
+// concurrently are left for the next run, the ones the policy doesn't allow to be published are skipped.
+func (s *Scheduler) PublishDue(ctx context.Context) (int, error) {
+	now := s.clock()
+	var due []*pb.Article
+	q := Query{Status: pb.ArticleStatus_SCHEDULED, Count: schedulerPageSize}
+	for {
+		page, err := s.db.Latest(ctx, q)
+		if err != nil {
+			return 0, err
+		}
+		for _, a := range page {
+			if at, err := ptypes.Timestamp(a.PublishAt); err == nil && !at.After(now) {
+				due = append(due, a)
+			}
+		}
+		if uint32(len(page)) < q.Count {
+			break
+		}
+		q.After = page[len(page)-1].Id
+	}
+
+	ctx = WithChange(ctx, Change{Editor: schedulerEditor})
+	published := 0
+	for _, cur := range due {
+		a := proto.Clone(cur).(*pb.Article)
+		a.Status = pb.ArticleStatus_PUBLISHED
+		if err := s.policy.Check(cur, a); err != nil {
+			log.Printf("cannot publish article %d: %s\n", a.Id, policyError(err, cur, a))
+			continue
+		}
+		_, err := s.db.Update(ctx, a, a.Version)
+		if err == ErrVersionConflict {
+			continue
+		}
+		if err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
@@ -0,0 +1,110 @@
+package articles
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/net/context"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// fakeArticles is an in-memory data store of the articles, it supports the queries of the scheduler only.
+type fakeArticles struct {
+	Factory
+
+	articles map[uint32]*pb.Article
+}
+
+func (f *fakeArticles) Latest(ctx context.Context, q Query) ([]*pb.Article, error) {
+	var res []*pb.Article
+	for _, a := range f.articles {
+		if a.Status == q.Status && (q.After == 0 || a.Id < q.After) {
+			res = append(res, proto.Clone(a).(*pb.Article))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Id > res[j].Id })
+	if uint32(len(res)) > q.Count {
+		res = res[:q.Count]
+	}
+	return res, nil
+}
+
+func (f *fakeArticles) Update(ctx context.Context, a *pb.Article, version uint32) (*pb.Article, error) {
+	cur, ok := f.articles[a.Id]
+	if !ok {
+		return nil, ErrArticleNotFound
+	}
+	if cur.Version != version {
+		return nil, ErrVersionConflict
+	}
+	a = proto.Clone(a).(*pb.Article)
+	a.Version++
+	f.articles[a.Id] = a
+	return a, nil
+}
+
+func TestSchedulerPublishDue(t *testing.T) {
+	now := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	ts := func(t time.Time) *timestamp.Timestamp {
+		p, _ := ptypes.TimestampProto(t)
+		return p
+	}
+	scheduled := func(id uint32, publishAt time.Time) *pb.Article {
+		return &pb.Article{Id: id, Title: "t", Body: "b", Category: "c", Status: pb.ArticleStatus_SCHEDULED, PublishAt: ts(publishAt), Version: 1}
+	}
+
+	tests := []struct {
+		name      string
+		article   *pb.Article
+		published bool
+		embargoed bool
+	}{
+		{"due", scheduled(1, now.Add(-time.Minute)), true, false},
+		{"due now", scheduled(1, now), true, false},
+		{"not due", scheduled(1, now.Add(time.Minute)), false, false},
+		{"due under embargo", func() *pb.Article {
+			a := scheduled(1, now.Add(-time.Minute))
+			a.EmbargoUntil = ts(now.Add(time.Hour))
+			return a
+		}(), true, true},
+	}
+	for _, tt := range tests {
+		db := &fakeArticles{articles: map[uint32]*pb.Article{tt.article.Id: tt.article}}
+		s := NewScheduler(db, func() time.Time { return now }, &DefaultPolicy)
+
+		n, err := s.PublishDue(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		a := db.articles[tt.article.Id]
+		if published := a.Status == pb.ArticleStatus_PUBLISHED; published != tt.published || (n == 1) != tt.published {
+			t.Errorf("%s: expected published %v, got %s after publishing %d articles", tt.name, tt.published, a.Status, n)
+		}
+		if embargoed := Embargoed(a, now); embargoed != tt.embargoed {
+			t.Errorf("%s: expected embargoed %v, got %v", tt.name, tt.embargoed, embargoed)
+		}
+	}
+}
+
+func TestSchedulerFollowsPolicy(t *testing.T) {
+	now := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	publishAt, _ := ptypes.TimestampProto(now.Add(-time.Minute))
+	db := &fakeArticles{articles: map[uint32]*pb.Article{
+		1: {Id: 1, Title: "t", Body: "b", Category: "c", Status: pb.ArticleStatus_SCHEDULED, PublishAt: publishAt, Version: 1},
+	}}
+	// the policy doesn't let the scheduled articles be published
+	s := NewScheduler(db, func() time.Time { return now }, &Policy{Editable: DefaultPolicy.Editable})
+
+	n, err := s.PublishDue(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("expected nothing to be published, got %d, %v", n, err)
+	}
+	if a := db.articles[1]; a.Status != pb.ArticleStatus_SCHEDULED {
+		t.Fatalf("expected the article to stay scheduled, got %s", a.Status)
+	}
+}
@@ -1,114 +1,600 @@
 package articles
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
+	"github.com/pavelnikolov/eventsourcing-go/validation"
+)
+
+const (
+	// eventsBatchSize is the maximum number of events read from the log at once.
+	eventsBatchSize = 100
+	// maxBatchGet is the maximum number of articles requested by BatchGetArticles.
+	maxBatchGet = 100
 )
 
 // package errors
 var (
-	ErrMissingBody     = errors.New("article body is required")
-	ErrMissingCategory = errors.New("article category is required")
-	ErrMissingTitle    = errors.New("article title is required")
-	ErrNilArticle      = errors.New("article is <nil>")
-	ErrUnknownStatus   = errors.New("unknown article status")
+	ErrArticleExists     = errors.New("article already exists")
+	ErrArticleNotFound   = errors.New("article not found")
+	ErrIDAllocated       = errors.New("article id is allocated by the server")
+	ErrIdempotencyKey    = errors.New("idempotency key has already been used for another article")
+	ErrInvalidSlug       = errors.New("article slug must consist of lower case letters, digits and dashes")
+	ErrInvalidTransition = errors.New("article status cannot be changed")
+	ErrMissingBody       = errors.New("article body is required")
+	ErrMissingCategory   = errors.New("article category is required")
+	ErrMissingPublishAt  = errors.New("article publish_at is required for scheduled articles")
+	ErrMissingTitle      = errors.New("article title is required")
+	ErrNilArticle        = errors.New("article is <nil>")
+	ErrNotEditable       = errors.New("article cannot be edited in its current status")
+	ErrRevisionNotFound  = errors.New("article revision not found")
+	ErrUnknownStatus     = errors.New("unknown article status")
+	ErrVersionConflict   = errors.New("article has been modified since the expected version")
 )
 
 // Factory is the interface of data store for articles.
 type Factory interface {
 	Get(ctx context.Context, id uint32) (*pb.Article, error)
+	// Create creates an article with the next free ID unless it has an ID already.
+	// It fails with ErrIdempotencyKey if an article has been created with the key of the change.
 	Create(ctx context.Context, a *pb.Article) (*pb.Article, error)
-	Update(ctx context.Context, a *pb.Article) (*pb.Article, error)
-	Latest(ctx context.Context, category string, count uint32, status pb.ArticleStatus) ([]*pb.Article, error)
+	// CreatedWith returns the ID of the article created with the idempotency key.
+	CreatedWith(ctx context.Context, key string) (uint32, error)
+	// Update modifies an article if its current version matches the expected one.
+	Update(ctx context.Context, a *pb.Article, version uint32) (*pb.Article, error)
+	// Latest returns the most recently created articles matching the query.
+	Latest(ctx context.Context, q Query) ([]*pb.Article, error)
+	// Revisions returns the revisions of an article, oldest first.
+	Revisions(ctx context.Context, id uint32) ([]*pb.ArticleRevision, error)
+}
+
+// Query selects the latest articles. Empty fields match all articles.
+type Query struct {
+	Category string
+	AuthorID uint32
+	Status   pb.ArticleStatus
+	Count    uint32
+	// After is the ID of the article the page starts after. Only the articles created
+	// before it are returned, so the pages don't shift when new articles are created.
+	After uint32
+	// Now hides the published articles which are under embargo at that time, none are hidden if it is zero.
+	Now time.Time
+}
+
+// Clock returns the current time. It is injected so that the scheduling can be tested deterministically.
+type Clock func() time.Time
+
+// Embargoed reports whether the article is published but kept out of the listings at t.
+func Embargoed(a *pb.Article, t time.Time) bool {
+	if a.Status != pb.ArticleStatus_PUBLISHED || a.EmbargoUntil == nil {
+		return false
+	}
+	until, err := ptypes.Timestamp(a.EmbargoUntil)
+	return err == nil && t.Before(until)
+}
+
+// EventLog is the interface of the append-only log of article events.
+type EventLog interface {
+	// Read returns up to limit events with position greater than after.
+	Read(ctx context.Context, after uint64, limit int) ([]*pb.Event, error)
+	// Head returns the position of the last event in the log.
+	Head(ctx context.Context) (uint64, error)
+	// Wait blocks until an event with position greater than after is appended or ctx is done.
+	Wait(ctx context.Context, after uint64) error
+}
+
+// Searcher is the interface of the full-text index of articles.
+type Searcher interface {
+	// Search returns the articles matching the request ordered by relevance and the number of all matches.
+	// It fails with a *QueryError if the query is malformed.
+	Search(ctx context.Context, in *pb.SearchArticlesRequest) ([]Hit, uint64, error)
+}
+
+// QueryError is returned by Searcher when the query of the request cannot be parsed.
+type QueryError struct {
+	Err error
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("invalid query: %v", e.Err)
+}
+
+// Hit is a single article found by Searcher.
+type Hit struct {
+	ID       uint32
+	Score    float64
+	Snippets []*pb.Snippet
 }
 
-// NewServer initialises an instance of the articles server.
-func NewServer(db Factory) *Server {
+// NewServer initialises an instance of the articles server. The clock decides which articles are under embargo
+// and the policy which changes of the articles are allowed.
+func NewServer(db Factory, events EventLog, search Searcher, clock Clock, policy *Policy) *Server {
 	if db == nil {
 		panic("db cannot be <nil>.")
 	}
-	return &Server{db: db}
+	if events == nil {
+		panic("events cannot be <nil>.")
+	}
+	if search == nil {
+		panic("search cannot be <nil>.")
+	}
+	if clock == nil {
+		panic("clock cannot be <nil>.")
+	}
+	if policy == nil {
+		panic("policy cannot be <nil>.")
+	}
+	return &Server{db: db, log: events, search: search, clock: clock, policy: policy}
 }
 
 // Server is used to implement publising.ArticlesServer.
 type Server struct {
-	db Factory
+	db     Factory
+	log    EventLog
+	search Searcher
+	clock  Clock
+	policy *Policy
 }
 
 // Article returns an article by ID.
 func (s *Server) Article(ctx context.Context, in *pb.ArticleRequest) (*pb.ArticleReply, error) {
 	a, err := s.db.Get(ctx, in.Id)
 	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get article: %v", err))
+		return nil, articleError(err, in.Id, fmt.Sprintf("failed to get article: %v", err))
 	}
 	return &pb.ArticleReply{Article: a}, nil
 }
 
-// CreateArticle creates an article.
+// CreateArticle creates an article with the next free ID. The retries of a request with
+// an idempotency key get the article created by the first one.
 func (s *Server) CreateArticle(ctx context.Context, in *pb.CreateArticleRequest) (*pb.ArticleReply, error) {
-	if err := validate(in.Article); err != nil {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invslid input: %v", err))
+	v := validate(in.Article)
+	if in.Article != nil && in.Article.Id != 0 {
+		v = append(v, validation.Violation("article.id", ErrIDAllocated))
+	}
+	if len(v) > 0 {
+		return nil, validation.InvalidInput(v)
+	}
+	if err := s.policy.Check(nil, in.Article); err != nil {
+		return nil, articleError(err, 0, fmt.Sprintf("failed to create article: %s", policyError(err, nil, in.Article)))
 	}
 
-	a, err := s.db.Create(ctx, in.Article)
+	a := proto.Clone(in.Article).(*pb.Article)
+	a.Created, a.Modified = s.now(), nil
+	res, err := s.db.Create(WithChange(ctx, Change{Editor: in.Editor, IdempotencyKey: in.IdempotencyKey}), a)
+	if err == ErrIdempotencyKey {
+		return s.retried(ctx, in)
+	}
+	if err != nil {
+		return nil, articleError(err, a.Id, fmt.Sprintf("failed to create article: %v", err))
+	}
+
+	return &pb.ArticleReply{Article: res}, nil
+}
+
+// retried returns the current state of the article created with the idempotency key of the request,
+// or AlreadyExists if the article was created with different content.
+func (s *Server) retried(ctx context.Context, in *pb.CreateArticleRequest) (*pb.ArticleReply, error) {
+	id, err := s.db.CreatedWith(ctx, in.IdempotencyKey)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create article: %v", err))
+	}
+	revs, err := s.db.Revisions(ctx, id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create article: %v", err))
 	}
+	if first := revs[0].Article; contentChanged(first, in.Article) || first.Status != in.Article.Status {
+		return nil, articleError(ErrIdempotencyKey, id, fmt.Sprintf("failed to create article: %v", ErrIdempotencyKey))
+	}
 
+	a, err := s.db.Get(ctx, id)
+	if err != nil {
+		return nil, articleError(err, id, fmt.Sprintf("failed to create article: %v", err))
+	}
 	return &pb.ArticleReply{Article: a}, nil
 }
 
 // UpdateArticle updates existing article.
 func (s *Server) UpdateArticle(ctx context.Context, in *pb.UpdateArticleRequest) (*pb.ArticleReply, error) {
-	if err := validate(in.Article); err != nil {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid input: %v", err))
+	v := validate(in.Article)
+	if in.ExpectedVersion == 0 {
+		v = append(v, &errdetails.BadRequest_FieldViolation{Field: "expected_version", Description: "expected_version is required"})
+	}
+	if len(v) > 0 {
+		return nil, validation.InvalidInput(v)
 	}
 
-	a, err := s.db.Update(ctx, in.Article)
+	return s.update(ctx, "update", in.Article, in.ExpectedVersion, Change{Editor: in.Editor})
+}
+
+// PublishArticle changes the status of an article to PUBLISHED.
+func (s *Server) PublishArticle(ctx context.Context, in *pb.PublishArticleRequest) (*pb.ArticleReply, error) {
+	return s.setStatus(ctx, "publish", in.Id, in.ExpectedVersion, pb.ArticleStatus_PUBLISHED, Change{Editor: in.Editor, Reason: in.Reason})
+}
+
+// RetractArticle changes the status of an article to RETRACTED.
+func (s *Server) RetractArticle(ctx context.Context, in *pb.RetractArticleRequest) (*pb.ArticleReply, error) {
+	return s.setStatus(ctx, "retract", in.Id, in.ExpectedVersion, pb.ArticleStatus_RETRACTED, Change{Editor: in.Editor, Reason: in.Reason})
+}
+
+// UnpublishArticle changes the status of an article to DRAFT.
+func (s *Server) UnpublishArticle(ctx context.Context, in *pb.UnpublishArticleRequest) (*pb.ArticleReply, error) {
+	return s.setStatus(ctx, "unpublish", in.Id, in.ExpectedVersion, pb.ArticleStatus_DRAFT, Change{Editor: in.Editor, Reason: in.Reason})
+}
+
+// setStatus changes the status of an article leaving the rest of it intact.
+func (s *Server) setStatus(ctx context.Context, op string, id, version uint32, st pb.ArticleStatus, c Change) (*pb.ArticleReply, error) {
+	if version == 0 {
+		return nil, validation.InvalidField("expected_version", "expected_version is required")
+	}
+	cur, err := s.db.Get(ctx, id)
 	if err != nil {
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update article: %v", err))
+		return nil, articleError(err, id, fmt.Sprintf("failed to %s article: %v", op, err))
 	}
 
-	return &pb.ArticleReply{Article: a}, nil
+	a := proto.Clone(cur).(*pb.Article)
+	a.Status = st
+	return s.update(ctx, op, a, version, c)
+}
+
+// update applies the change to an article if the policy allows it and the article is still at the expected version.
+func (s *Server) update(ctx context.Context, op string, a *pb.Article, version uint32, c Change) (*pb.ArticleReply, error) {
+	cur, err := s.db.Get(ctx, a.Id)
+	if err != nil {
+		return nil, articleError(err, a.Id, fmt.Sprintf("failed to %s article: %v", op, err))
+	}
+	if cur.Version != version {
+		return nil, articleError(ErrVersionConflict, a.Id, fmt.Sprintf("failed to %s article: %v", op, ErrVersionConflict))
+	}
+	if err := s.policy.Check(cur, a); err != nil {
+		return nil, articleError(err, a.Id, fmt.Sprintf("failed to %s article: %s", op, policyError(err, cur, a)))
+	}
+
+	// the timestamps are kept by the server, the articles are modified only when their content changes
+	a = proto.Clone(a).(*pb.Article)
+	a.Created, a.Modified = cur.Created, cur.Modified
+	if contentChanged(cur, a) {
+		a.Modified = s.now()
+	}
+
+	res, err := s.db.Update(WithChange(ctx, c), a, version)
+	if err != nil {
+		return nil, articleError(err, a.Id, fmt.Sprintf("failed to %s article: %v", op, err))
+	}
+
+	return &pb.ArticleReply{Article: res}, nil
 }
 
 // LatestArticles queries for latest articles by the given params.
 func (s *Server) LatestArticles(ctx context.Context, in *pb.LatestArticlesRequest) (*pb.ArticlesReply, error) {
 	if in.Count == 0 {
-		return nil, status.Error(codes.InvalidArgument, "count cannot be 0")
+		return nil, validation.InvalidField("count", "count cannot be 0")
 	}
 	if in.Count > 50 {
-		return nil, status.Error(codes.InvalidArgument, "count cannot be greater than 50")
+		return nil, validation.InvalidField("count", "count cannot be greater than 50")
+	}
+	var after uint32
+	if in.PageToken != "" {
+		var err error
+		if after, err = parsePageToken(in.PageToken); err != nil {
+			return nil, validation.InvalidField("page_token", fmt.Sprintf("invalid page token: %v", err))
+		}
+	}
+
+	q := Query{
+		Category: in.Category,
+		AuthorID: in.AuthorId,
+		Status:   in.Status,
+		// fetch one more article to find out whether there is a next page
+		Count: in.Count + 1,
+		After: after,
+		Now:   s.clock(),
+	}
+	res, err := s.db.Latest(ctx, q)
+	if err == ErrArticleNotFound {
+		return nil, validation.InvalidField("page_token", "invalid page token: unknown article")
 	}
-	res, err := s.db.Latest(ctx, in.Category, in.Count, in.Status)
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get latest articles: %v", err))
 	}
 
-	return &pb.ArticlesReply{Articles: res}, nil
+	reply := &pb.ArticlesReply{Articles: res}
+	if uint32(len(res)) > in.Count {
+		reply.Articles = res[:in.Count]
+		reply.NextPageToken = pageToken(res[in.Count-1].Id)
+	}
+	for _, a := range reply.Articles {
+		reply.Cursors = append(reply.Cursors, pageToken(a.Id))
+	}
+	return reply, nil
 }
 
-func validate(a *pb.Article) error {
+// SearchArticles performs a full-text search of the articles.
+func (s *Server) SearchArticles(ctx context.Context, in *pb.SearchArticlesRequest) (*pb.SearchArticlesReply, error) {
+	if in.Query == "" {
+		return nil, validation.InvalidField("query", "query cannot be empty")
+	}
+	if in.Count == 0 {
+		return nil, validation.InvalidField("count", "count cannot be 0")
+	}
+	if in.Count > 50 {
+		return nil, validation.InvalidField("count", "count cannot be greater than 50")
+	}
+	hits, total, err := s.search.Search(ctx, in)
+	if qe, ok := err.(*QueryError); ok {
+		return nil, validation.InvalidField("query", qe.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to search articles: %v", err))
+	}
+
+	res := &pb.SearchArticlesReply{Total: total}
+	for _, h := range hits {
+		a, err := s.db.Get(ctx, h.ID)
+		if err == ErrArticleNotFound {
+			// the index is eventually consistent and may be ahead of a replica of the data store
+			continue
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get article: %v", err))
+		}
+		res.Hits = append(res.Hits, &pb.SearchHit{Article: a, Score: h.Score, Snippets: h.Snippets})
+	}
+
+	return res, nil
+}
+
+// BatchGetArticles returns the existing articles by ID in the requested order.
+func (s *Server) BatchGetArticles(ctx context.Context, in *pb.BatchGetRequest) (*pb.ArticlesReply, error) {
+	if len(in.Ids) > maxBatchGet {
+		return nil, validation.InvalidField("ids", fmt.Sprintf("cannot get more than %d articles at once", maxBatchGet))
+	}
+
+	res := &pb.ArticlesReply{}
+	for _, id := range in.Ids {
+		a, err := s.db.Get(ctx, id)
+		if err == ErrArticleNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get article: %v", err))
+		}
+		res.Articles = append(res.Articles, a)
+	}
+
+	return res, nil
+}
+
+// ListArticleRevisions returns the revisions of an article, newest first.
+func (s *Server) ListArticleRevisions(ctx context.Context, in *pb.ListArticleRevisionsRequest) (*pb.ArticleRevisionsReply, error) {
+	revs, err := s.db.Revisions(ctx, in.Id)
+	if err != nil {
+		return nil, articleError(err, in.Id, fmt.Sprintf("failed to get revisions: %v", err))
+	}
+
+	res := &pb.ArticleRevisionsReply{}
+	for i := len(revs) - 1; i >= 0; i-- {
+		res.Revisions = append(res.Revisions, revs[i])
+	}
+	return res, nil
+}
+
+// GetArticleRevision returns a single revision of an article by the version of the article after it.
+func (s *Server) GetArticleRevision(ctx context.Context, in *pb.GetArticleRevisionRequest) (*pb.ArticleRevisionReply, error) {
+	rev, err := s.revision(ctx, in.Id, in.Version)
+	if err != nil {
+		return nil, articleError(err, in.Id, fmt.Sprintf("failed to get revision: %v", err))
+	}
+	return &pb.ArticleRevisionReply{Revision: rev}, nil
+}
+
+// RevertArticle restores the title, slug, body, category and author of an older revision of an article.
+// The status of the article is kept, so reverting a published article doesn't unpublish it.
+func (s *Server) RevertArticle(ctx context.Context, in *pb.RevertArticleRequest) (*pb.ArticleReply, error) {
+	var v []*errdetails.BadRequest_FieldViolation
+	if in.Version == 0 {
+		v = append(v, &errdetails.BadRequest_FieldViolation{Field: "version", Description: "version is required"})
+	}
+	if in.ExpectedVersion == 0 {
+		v = append(v, &errdetails.BadRequest_FieldViolation{Field: "expected_version", Description: "expected_version is required"})
+	}
+	if len(v) > 0 {
+		return nil, validation.InvalidInput(v)
+	}
+
+	rev, err := s.revision(ctx, in.Id, in.Version)
+	if err != nil {
+		return nil, articleError(err, in.Id, fmt.Sprintf("failed to revert article: %v", err))
+	}
+	cur, err := s.db.Get(ctx, in.Id)
+	if err != nil {
+		return nil, articleError(err, in.Id, fmt.Sprintf("failed to revert article: %v", err))
+	}
+
+	a := proto.Clone(cur).(*pb.Article)
+	a.Title, a.Slug, a.Body, a.Category = rev.Article.Title, rev.Article.Slug, rev.Article.Body, rev.Article.Category
+	a.AuthorId, a.AuthorName = rev.Article.AuthorId, rev.Article.AuthorName
+	return s.update(ctx, "revert", a, in.ExpectedVersion, Change{Editor: in.Editor, CausationID: rev.Id})
+}
+
+// now returns the current time of the server clock.
+func (s *Server) now() *timestamp.Timestamp {
+	// the clock never leaves the range of the timestamps, which ends in the year 10000
+	t, _ := ptypes.TimestampProto(s.clock())
+	return t
+}
+
+// revision returns the revision of an article after which the article has the given version.
+func (s *Server) revision(ctx context.Context, id, version uint32) (*pb.ArticleRevision, error) {
+	revs, err := s.db.Revisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, rev := range revs {
+		if rev.Version == version {
+			return rev, nil
+		}
+	}
+	return nil, ErrRevisionNotFound
+}
+
+// SubscribeEvents streams article events from the requested position until the client disconnects.
+// The log is identified by the ID of its first event, so the clients can tell when it has been replaced.
+func (s *Server) SubscribeEvents(in *pb.SubscribeEventsRequest, stream pb.Articles_SubscribeEventsServer) error {
+	ctx := stream.Context()
+	head, err := s.log.Head(ctx)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("failed to get log head: %v", err))
+	}
+
+	var pos uint64
+	switch in.Start {
+	case pb.SubscribeEventsRequest_BEGINNING:
+	case pb.SubscribeEventsRequest_LATEST:
+		pos = head
+	case pb.SubscribeEventsRequest_AFTER:
+		if in.Position > head {
+			return status.Error(codes.OutOfRange, fmt.Sprintf("position %d is after the last event %d", in.Position, head))
+		}
+		pos = in.Position
+	default:
+		return validation.InvalidField("start", fmt.Sprintf("unknown start %v", in.Start))
+	}
+	if in.LogId != "" {
+		first, err := s.log.Read(ctx, 0, 1)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to read events: %v", err))
+		}
+		if len(first) == 0 || first[0].Id != in.LogId {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("event log %s has been replaced", in.LogId))
+		}
+	}
+
+	// the header tells the client that the subscription is live before the first event
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		events, err := s.log.Read(ctx, pos, eventsBatchSize)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to read events: %v", err))
+		}
+		for _, e := range events {
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+			pos = e.Position
+		}
+		if len(events) > 0 {
+			continue
+		}
+		switch err := s.log.Wait(ctx, pos); err {
+		case nil:
+		case context.Canceled:
+			return status.Error(codes.Canceled, err.Error())
+		case context.DeadlineExceeded:
+			return status.Error(codes.DeadlineExceeded, err.Error())
+		default:
+			return status.Error(codes.Internal, fmt.Sprintf("failed to wait for events: %v", err))
+		}
+	}
+}
+
+// pageToken returns an opaque token of the page which starts after the article.
+func pageToken(id uint32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("after:%d", id)))
+}
+
+func parsePageToken(token string) (uint32, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	var id uint32
+	if _, err := fmt.Sscanf(string(data), "after:%d", &id); err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return 0, errors.New("unknown article")
+	}
+	return id, nil
+}
+
+// validate returns the violations of the article fields, the field paths are relative to the request.
+func validate(a *pb.Article) []*errdetails.BadRequest_FieldViolation {
 	if a == nil {
-		return ErrNilArticle
+		return []*errdetails.BadRequest_FieldViolation{validation.Violation("article", ErrNilArticle)}
 	}
+	var res []*errdetails.BadRequest_FieldViolation
 	if a.Body == "" {
-		return ErrMissingBody
+		res = append(res, validation.Violation("article.body", ErrMissingBody))
 	}
 	if a.Category == "" {
-		return ErrMissingCategory
+		res = append(res, validation.Violation("article.category", ErrMissingCategory))
 	}
 	if a.Title == "" {
-		return ErrMissingTitle
+		res = append(res, validation.Violation("article.title", ErrMissingTitle))
 	}
 	if a.Status == pb.ArticleStatus_UNKNOWN {
-		return ErrUnknownStatus
+		res = append(res, validation.Violation("article.status", ErrUnknownStatus))
+	}
+	if a.Status == pb.ArticleStatus_SCHEDULED && a.PublishAt == nil {
+		res = append(res, validation.Violation("article.publish_at", ErrMissingPublishAt))
+	}
+	if a.Slug != "" && urls.Slug(a.Slug) != a.Slug {
+		res = append(res, validation.Violation("article.slug", ErrInvalidSlug))
+	}
+	return res
+}
+
+// articleError converts the error of an operation on an article to a gRPC error with msg. The errors of
+// the domain get their own codes and the ResourceInfo of the article, unless the article has no ID yet.
+// The errors of the editorial policy carry a PreconditionFailure too. Any other error is Internal.
+func articleError(err error, id uint32, msg string) error {
+	var code codes.Code
+	switch err {
+	case ErrArticleNotFound, ErrRevisionNotFound:
+		code = codes.NotFound
+	case ErrArticleExists, ErrIdempotencyKey:
+		code = codes.AlreadyExists
+	case ErrVersionConflict:
+		code = codes.Aborted
+	case ErrInvalidTransition, ErrNotEditable:
+		code = codes.FailedPrecondition
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+
+	var details []proto.Message
+	if id != 0 {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: proto.MessageName(&pb.Article{}),
+			ResourceName: fmt.Sprintf("articles/%d", id),
+			Description:  err.Error(),
+		})
+	}
+	if code == codes.FailedPrecondition {
+		details = append(details, &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "POLICY", Subject: "article.status", Description: msg},
+		}})
+	}
+	st := status.New(code, msg)
+	if ds, err := st.WithDetails(details...); err == nil {
+		st = ds
 	}
-	return nil
+	return st.Err()
 }
@@ -0,0 +1,143 @@
+package authors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/validation"
+)
+
+// maxBatchGet is the maximum number of authors requested by BatchGetAuthors.
+const maxBatchGet = 100
+
+// package errors
+var (
+	ErrAuthorExists    = errors.New("author already exists")
+	ErrAuthorNotFound  = errors.New("author not found")
+	ErrInvalidEmail    = errors.New("author email is invalid")
+	ErrMissingID       = errors.New("author id is required")
+	ErrMissingName     = errors.New("author name is required")
+	ErrNilAuthor       = errors.New("author is <nil>")
+	ErrVersionConflict = errors.New("author has been modified since the expected version")
+)
+
+// Factory is the interface of data store for authors.
+type Factory interface {
+	Get(ctx context.Context, id uint32) (*pb.Author, error)
+	Create(ctx context.Context, a *pb.Author) (*pb.Author, error)
+	// Update modifies an author if its current version matches the expected one.
+	Update(ctx context.Context, a *pb.Author, version uint32) (*pb.Author, error)
+}
+
+// NewServer initialises an instance of the authors server.
+func NewServer(db Factory) *Server {
+	if db == nil {
+		panic("db cannot be <nil>.")
+	}
+	return &Server{db: db}
+}
+
+// Server is used to implement publising.AuthorsServer.
+type Server struct {
+	db Factory
+}
+
+// Author returns an author by ID.
+func (s *Server) Author(ctx context.Context, in *pb.AuthorRequest) (*pb.AuthorReply, error) {
+	a, err := s.db.Get(ctx, in.Id)
+	if err == ErrAuthorNotFound {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("failed to get author: %v", err))
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get author: %v", err))
+	}
+	return &pb.AuthorReply{Author: a}, nil
+}
+
+// CreateAuthor creates an author with the ID of the request, which must not be 0.
+func (s *Server) CreateAuthor(ctx context.Context, in *pb.CreateAuthorRequest) (*pb.AuthorReply, error) {
+	v := validate(in.Author)
+	if in.Author != nil && in.Author.Id == 0 {
+		v = append(v, validation.Violation("author.id", ErrMissingID))
+	}
+	if len(v) > 0 {
+		return nil, validation.InvalidInput(v)
+	}
+
+	a, err := s.db.Create(ctx, in.Author)
+	if err == ErrAuthorExists {
+		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("failed to create author: %v", err))
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create author: %v", err))
+	}
+
+	return &pb.AuthorReply{Author: a}, nil
+}
+
+// UpdateAuthor updates existing author. Renaming the author renames all of their articles.
+func (s *Server) UpdateAuthor(ctx context.Context, in *pb.UpdateAuthorRequest) (*pb.AuthorReply, error) {
+	v := validate(in.Author)
+	if in.ExpectedVersion == 0 {
+		v = append(v, &errdetails.BadRequest_FieldViolation{Field: "expected_version", Description: "expected_version is required"})
+	}
+	if len(v) > 0 {
+		return nil, validation.InvalidInput(v)
+	}
+
+	a, err := s.db.Update(ctx, in.Author, in.ExpectedVersion)
+	switch err {
+	case nil:
+	case ErrAuthorNotFound:
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("failed to update author: %v", err))
+	case ErrVersionConflict:
+		return nil, status.Error(codes.Aborted, fmt.Sprintf("failed to update author: %v", err))
+	default:
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update author: %v", err))
+	}
+
+	return &pb.AuthorReply{Author: a}, nil
+}
+
+// BatchGetAuthors returns the existing authors by ID in the requested order.
+func (s *Server) BatchGetAuthors(ctx context.Context, in *pb.BatchGetRequest) (*pb.AuthorsReply, error) {
+	if len(in.Ids) > maxBatchGet {
+		return nil, validation.InvalidField("ids", fmt.Sprintf("cannot get more than %d authors at once", maxBatchGet))
+	}
+
+	res := &pb.AuthorsReply{}
+	for _, id := range in.Ids {
+		a, err := s.db.Get(ctx, id)
+		if err == ErrAuthorNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get author: %v", err))
+		}
+		res.Authors = append(res.Authors, a)
+	}
+
+	return res, nil
+}
+
+// validate returns the violations of the author fields, the field paths are relative to the request.
+func validate(a *pb.Author) []*errdetails.BadRequest_FieldViolation {
+	if a == nil {
+		return []*errdetails.BadRequest_FieldViolation{validation.Violation("author", ErrNilAuthor)}
+	}
+	var res []*errdetails.BadRequest_FieldViolation
+	if a.Name == "" {
+		res = append(res, validation.Violation("author.name", ErrMissingName))
+	}
+	if a.Email != "" && !strings.Contains(a.Email, "@") {
+		res = append(res, validation.Violation("author.email", ErrInvalidEmail))
+	}
+	return res
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+func (r *queryResolver) Author(ctx context.Context, args struct{ ID graphql.ID }) (*authorResolver, error) {
+	aid, err := unmarshalID(args.ID, authorKind, "id")
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.author(ctx, aid)
+	if err != nil {
+		return nil, translate(err, "failed to get author", "")
+	}
+	if a == nil {
+		return nil, notFound(fmt.Sprintf("failed to get author: author %d not found", aid), authorKind, aid)
+	}
+
+	return &authorResolver{root: r, author: a}, nil
+}
+
+type authorResolver struct {
+	root   *queryResolver
+	author *pb.Author
+}
+
+func (r *authorResolver) ID() graphql.ID {
+	return relay.MarshalID(authorKind, r.author.Id)
+}
+
+func (r *authorResolver) Name() string {
+	return r.author.Name
+}
+
+func (r *authorResolver) Bio() string {
+	return r.author.Bio
+}
+
+func (r *authorResolver) Email() string {
+	return r.author.Email
+}
+
+func (r *authorResolver) AvatarURL() string {
+	return r.author.AvatarUrl
+}
+
+func (r *authorResolver) Version() int32 {
+	return int32(r.author.Version)
+}
+
+func (r *authorResolver) Articles(ctx context.Context, args struct {
+	First  int32
+	After  *string
+	Status string
+}) (*articleConnectionResolver, error) {
+	req := &pb.LatestArticlesRequest{
+		AuthorId: r.author.Id,
+		Count:    uint32(args.First),
+		Status:   pb.ArticleStatus(pb.ArticleStatus_value[args.Status]),
+	}
+	if args.After != nil {
+		req.PageToken = *args.After
+	}
+	res, err := r.root.client.LatestArticles(ctx, req)
+	if err != nil {
+		return nil, translate(err, "failed to get articles", "")
+	}
+
+	return &articleConnectionResolver{root: r.root, res: res}, nil
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// dateTime is the DateTime scalar, an RFC 3339 timestamp in UTC.
+type dateTime struct {
+	time.Time
+}
+
+// newDateTime returns nil if ts is not set.
+func newDateTime(ts *tspb.Timestamp) *dateTime {
+	if ts == nil {
+		return nil
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return nil
+	}
+	return &dateTime{t.UTC()}
+}
+
+// timestamp returns nil if t is nil. The input field the time comes from is reported under path.
+func (t *dateTime) timestamp(path ...string) (*tspb.Timestamp, error) {
+	if t == nil {
+		return nil, nil
+	}
+	ts, err := ptypes.TimestampProto(t.Time)
+	if err != nil {
+		return nil, &inputError{
+			msg:        fmt.Sprintf("invalid DateTime %q: %v", t.Time.Format(time.RFC3339), err),
+			violations: []violation{{Path: path, Message: "invalid DateTime"}},
+		}
+	}
+	return ts, nil
+}
+
+func (dateTime) ImplementsGraphQLType(name string) bool {
+	return name == "DateTime"
+}
+
+func (t *dateTime) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for DateTime: %T", input)
+	}
+	var err error
+	t.Time, err = time.Parse(time.RFC3339, s)
+	return err
+}
+
+func (t dateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// error codes reported in the extensions of GraphQL errors
+const (
+	codeBadUserInput       = "BAD_USER_INPUT"
+	codeConflict           = "CONFLICT"
+	codeFailedPrecondition = "FAILED_PRECONDITION"
+	codeNotFound           = "NOT_FOUND"
+	codeUnavailable        = "UNAVAILABLE"
+)
+
+// resource is the object an error is about.
+type resource struct {
+	Type string     `json:"type"`
+	ID   graphql.ID `json:"id"`
+}
+
+func newResource(kind string, id uint32) *resource {
+	typ := "Article"
+	if kind == authorKind {
+		typ = "Author"
+	}
+	return &resource{Type: typ, ID: relay.MarshalID(kind, id)}
+}
+
+// violation is an invalid field of the input of a mutation.
+type violation struct {
+	Path    []string `json:"path"`
+	Message string   `json:"message"`
+}
+
+// inputError is a GraphQL error which points the client to the invalid fields of its input
+// or to the object the request failed on, e.g. an article which doesn't exist.
+type inputError struct {
+	msg        string
+	code       string
+	violations []violation
+	resource   *resource
+}
+
+func (e *inputError) Error() string {
+	return e.msg
+}
+
+// Extensions is reported in the "extensions" field of the GraphQL error.
+func (e *inputError) Extensions() map[string]interface{} {
+	code := e.code
+	if code == "" {
+		code = codeBadUserInput
+	}
+	res := map[string]interface{}{"code": code}
+	if len(e.violations) > 0 {
+		res["fields"] = e.violations
+	}
+	if e.resource != nil {
+		res["resource"] = e.resource
+	}
+	return res
+}
+
+// notFound returns the error of a query for an object which doesn't exist.
+func notFound(msg, kind string, id uint32) error {
+	return &inputError{msg: msg, code: codeNotFound, resource: newResource(kind, id)}
+}
+
+// translate converts the errors of the articles service to GraphQL errors. The field
+// violations of an article are reported under path, the argument holding the article input.
+func translate(err error, msg, path string) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("%s: %v", msg, err)
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		return &inputError{msg: fmt.Sprintf("%s: %s", msg, st.Message()), code: codeNotFound, resource: resourceOf(st)}
+	case codes.InvalidArgument:
+		res := &inputError{msg: fmt.Sprintf("%s: %s", msg, st.Message())}
+		for _, d := range st.Details() {
+			br, ok := d.(*errdetails.BadRequest)
+			if !ok {
+				continue
+			}
+			for _, v := range br.FieldViolations {
+				res.violations = append(res.violations, violation{Path: fieldPath(v.Field, path), Message: v.Description})
+			}
+		}
+		return res
+	case codes.Aborted, codes.AlreadyExists:
+		return &inputError{msg: fmt.Sprintf("%s: %s", msg, st.Message()), code: codeConflict, resource: resourceOf(st)}
+	case codes.FailedPrecondition:
+		return &inputError{msg: fmt.Sprintf("%s: %s", msg, st.Message()), code: codeFailedPrecondition, resource: resourceOf(st)}
+	case codes.Unavailable:
+		// the clients may retry the request later
+		return &inputError{msg: fmt.Sprintf("%s: %s", msg, st.Message()), code: codeUnavailable}
+	}
+	return fmt.Errorf("%s: %v", msg, err)
+}
+
+// resourceOf returns the object reported in the ResourceInfo details of the status, e.g. the article
+// in conflict. It is nil if there is no such detail or the object has no GraphQL type.
+func resourceOf(st *status.Status) *resource {
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ResourceInfo)
+		if !ok {
+			continue
+		}
+		var id uint32
+		if info.ResourceType != proto.MessageName(&pb.Article{}) {
+			continue
+		}
+		if _, err := fmt.Sscanf(info.ResourceName, "articles/%d", &id); err != nil {
+			continue
+		}
+		return newResource(articleKind, id)
+	}
+	return nil
+}
+
+// fieldPath converts a field of the gRPC request, e.g. "article.title", to
+// the path of the GraphQL argument, e.g. ["input", "title"].
+func fieldPath(field, path string) []string {
+	if field == "article" {
+		return []string{path}
+	}
+	if strings.HasPrefix(field, "article.") {
+		return []string{path, strings.TrimPrefix(field, "article.")}
+	}
+	return []string{field}
+}
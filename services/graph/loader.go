@@ -0,0 +1,210 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+const (
+	// batchWait is how long the loaders collect the keys of a batch.
+	batchWait = time.Millisecond
+	// maxBatch is the maximum number of keys fetched at once, as accepted by the batch RPCs.
+	maxBatch = 100
+)
+
+type loadersKey struct{}
+
+// loaders are the request-scoped loaders of the entities.
+type loaders struct {
+	articles *loader
+	authors  *loader
+}
+
+// withLoaders attaches new loaders to the context of every request, so the entities
+// requested by the resolvers of a single query are fetched in batches and cached.
+func withLoaders(h http.Handler, c pb.ArticlesClient, a pb.AuthorsClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := &loaders{
+			articles: newLoader(ctx, fetchArticles(c)),
+			authors:  newLoader(ctx, fetchAuthors(a)),
+		}
+		h.ServeHTTP(w, r.WithContext(context.WithValue(ctx, loadersKey{}, l)))
+	})
+}
+
+// loadersFrom returns nil if there are no loaders in the context, e.g. in the long-lived
+// subscriptions which must not cache the entities.
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
+
+func fetchArticles(c pb.ArticlesClient) fetchFunc {
+	return func(ctx context.Context, ids []uint32) (map[uint32]interface{}, error) {
+		res, err := c.BatchGetArticles(ctx, &pb.BatchGetRequest{Ids: ids})
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[uint32]interface{})
+		for _, a := range res.Articles {
+			m[a.Id] = a
+		}
+		return m, nil
+	}
+}
+
+func fetchAuthors(c pb.AuthorsClient) fetchFunc {
+	return func(ctx context.Context, ids []uint32) (map[uint32]interface{}, error) {
+		res, err := c.BatchGetAuthors(ctx, &pb.BatchGetRequest{Ids: ids})
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[uint32]interface{})
+		for _, a := range res.Authors {
+			m[a.Id] = a
+		}
+		return m, nil
+	}
+}
+
+// fetchFunc returns the entities by ID, the entities which don't exist are left out.
+type fetchFunc func(ctx context.Context, ids []uint32) (map[uint32]interface{}, error)
+
+// loader collects the keys requested within batchWait and fetches them at once.
+// The results are cached for the lifetime of the loader.
+type loader struct {
+	ctx   context.Context
+	fetch fetchFunc
+
+	cache map[uint32]*result
+	// batch are the keys waiting to be fetched
+	batch *batch
+	mu    sync.Mutex
+}
+
+type batch struct {
+	ids []uint32
+}
+
+type result struct {
+	// done is closed when the value is fetched
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// newLoader returns a loader which fetches the entities within ctx.
+func newLoader(ctx context.Context, fetch fetchFunc) *loader {
+	return &loader{ctx: ctx, fetch: fetch, cache: make(map[uint32]*result)}
+}
+
+// Load returns the entity with the given ID or nil if it doesn't exist.
+func (l *loader) Load(ctx context.Context, id uint32) (interface{}, error) {
+	l.mu.Lock()
+	r, ok := l.cache[id]
+	if !ok {
+		r = &result{done: make(chan struct{})}
+		l.cache[id] = r
+		if l.batch == nil {
+			b := &batch{}
+			l.batch = b
+			time.AfterFunc(batchWait, func() { l.dispatch(b) })
+		}
+		l.batch.ids = append(l.batch.ids, id)
+		if len(l.batch.ids) == maxBatch {
+			// the full batch is detached right away, so the following keys start a new one
+			ids := l.batch.ids
+			l.batch = nil
+			go l.load(ids)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// dispatch fetches the batch unless it has been dispatched already.
+func (l *loader) dispatch(b *batch) {
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+	ids := b.ids
+	l.batch = nil
+	l.mu.Unlock()
+
+	l.load(ids)
+}
+
+// load fetches the keys of a detached batch and resolves their results.
+func (l *loader) load(ids []uint32) {
+	values, err := l.fetch(l.ctx, ids)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range ids {
+		r := l.cache[id]
+		r.value, r.err = values[id], err
+		if err != nil {
+			// don't cache the failures so they can be retried
+			delete(l.cache, id)
+		}
+		close(r.done)
+	}
+}
+
+// article returns nil if the article doesn't exist. It is batched with the other articles of the request.
+func (r *queryResolver) article(ctx context.Context, id uint32) (*pb.Article, error) {
+	l := loadersFrom(ctx)
+	if l == nil {
+		res, err := r.client.Article(ctx, &pb.ArticleRequest{Id: id})
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return res.Article, nil
+	}
+
+	v, err := l.articles.Load(ctx, id)
+	if v == nil || err != nil {
+		return nil, err
+	}
+	return v.(*pb.Article), nil
+}
+
+// author returns nil if the author doesn't exist. It is batched with the other authors of the request.
+func (r *queryResolver) author(ctx context.Context, id uint32) (*pb.Author, error) {
+	l := loadersFrom(ctx)
+	if l == nil {
+		res, err := r.authors.Author(ctx, &pb.AuthorRequest{Id: id})
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return res.Author, nil
+	}
+
+	v, err := l.authors.Load(ctx, id)
+	if v == nil || err != nil {
+		return nil, err
+	}
+	return v.(*pb.Author), nil
+}
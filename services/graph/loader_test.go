@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// fakeArticles is an articles client which records the batches it is asked for.
+// Every article exists, except the ones with ID 0.
+type fakeArticles struct {
+	pb.ArticlesClient
+
+	batches [][]uint32
+	mu      sync.Mutex
+}
+
+func (c *fakeArticles) BatchGetArticles(ctx context.Context, in *pb.BatchGetRequest, opts ...grpc.CallOption) (*pb.ArticlesReply, error) {
+	c.mu.Lock()
+	c.batches = append(c.batches, in.Ids)
+	c.mu.Unlock()
+
+	if len(in.Ids) > maxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot get more than %d articles at once", maxBatch)
+	}
+	res := &pb.ArticlesReply{}
+	for _, id := range in.Ids {
+		if id != 0 {
+			res.Articles = append(res.Articles, &pb.Article{Id: id})
+		}
+	}
+	return res, nil
+}
+
+func (c *fakeArticles) calls() [][]uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.batches
+}
+
+// loadAll loads the articles concurrently and fails the test if any of them is missing.
+func loadAll(t *testing.T, l *loader, ids []uint32) {
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id uint32) {
+			defer wg.Done()
+			v, err := l.Load(context.Background(), id)
+			if err != nil {
+				t.Errorf("failed to load article %d: %v", id, err)
+				return
+			}
+			if a, ok := v.(*pb.Article); !ok || a.Id != id {
+				t.Errorf("got %v for article %d", v, id)
+			}
+		}(id)
+	}
+	wg.Wait()
+}
+
+func TestLoaderBatchesKeysOfTick(t *testing.T) {
+	c := &fakeArticles{}
+	l := newLoader(context.Background(), fetchArticles(c))
+
+	loadAll(t, l, []uint32{1, 2, 3, 4, 5})
+
+	if calls := c.calls(); len(calls) != 1 || len(calls[0]) != 5 {
+		t.Fatalf("expected a single batch of 5 articles, got %v", calls)
+	}
+}
+
+func TestLoaderCachesResults(t *testing.T) {
+	c := &fakeArticles{}
+	l := newLoader(context.Background(), fetchArticles(c))
+
+	loadAll(t, l, []uint32{1, 2})
+	loadAll(t, l, []uint32{2, 1, 2})
+
+	if calls := c.calls(); len(calls) != 1 {
+		t.Fatalf("expected the second loads to hit the cache, got %v", calls)
+	}
+
+	// the missing articles are cached too
+	for i := 0; i < 2; i++ {
+		v, err := l.Load(context.Background(), 0)
+		if v != nil || err != nil {
+			t.Fatalf("expected no article, got %v, %v", v, err)
+		}
+	}
+	if calls := c.calls(); len(calls) != 2 {
+		t.Fatalf("expected one more call, got %v", calls)
+	}
+}
+
+func TestLoaderSplitsBatches(t *testing.T) {
+	c := &fakeArticles{}
+	l := newLoader(context.Background(), fetchArticles(c))
+
+	var ids []uint32
+	for i := 1; i <= 3000; i++ {
+		ids = append(ids, uint32(i))
+	}
+	// the loads queue up behind the lock, so they race the dispatch of every full batch
+	l.mu.Lock()
+	done := make(chan struct{})
+	go func() {
+		loadAll(t, l, ids)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	l.mu.Unlock()
+	<-done
+
+	total := 0
+	for _, b := range c.calls() {
+		if len(b) > maxBatch {
+			t.Fatalf("batch of %d articles is over the limit of %d", len(b), maxBatch)
+		}
+		total += len(b)
+	}
+	if total != len(ids) {
+		t.Fatalf("expected %d articles to be fetched once, got %d", len(ids), total)
+	}
+	if calls := len(c.calls()); calls < len(ids)/maxBatch {
+		t.Fatalf("expected at least %d calls, got %d", len(ids)/maxBatch, calls)
+	}
+}
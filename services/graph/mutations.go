@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/graph-gophers/graphql-go"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+type articleInput struct {
+	Title        string
+	Body         string
+	Category     string
+	AuthorID     graphql.ID
+	Status       string
+	Slug         *string
+	PublishAt    *dateTime
+	EmbargoUntil *dateTime
+}
+
+// article converts the input to an article, the fields of the input are reported under path.
+func (in *articleInput) article(path string, id uint32) (*pb.Article, error) {
+	authorID, err := unmarshalID(in.AuthorID, authorKind, path, "author_id")
+	if err != nil {
+		return nil, err
+	}
+	a := &pb.Article{
+		Id:       id,
+		Title:    in.Title,
+		Body:     in.Body,
+		Category: in.Category,
+		AuthorId: authorID,
+		Status:   pb.ArticleStatus(pb.ArticleStatus_value[in.Status]),
+	}
+	if in.Slug != nil {
+		a.Slug = *in.Slug
+	}
+	if a.PublishAt, err = in.PublishAt.timestamp(path, "publish_at"); err != nil {
+		return nil, err
+	}
+	if a.EmbargoUntil, err = in.EmbargoUntil.timestamp(path, "embargo_until"); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *queryResolver) CreateArticle(ctx context.Context, args struct {
+	Input          *articleInput
+	IdempotencyKey *string
+	Editor         *string
+}) (*articleResolver, error) {
+	a, err := args.Input.article("input", 0)
+	if err != nil {
+		return nil, err
+	}
+	in := &pb.CreateArticleRequest{Article: a, Editor: optional(args.Editor), IdempotencyKey: optional(args.IdempotencyKey)}
+	res, err := r.client.CreateArticle(ctx, in)
+	if err != nil {
+		return nil, translate(err, "failed to create article", "input")
+	}
+
+	return &articleResolver{root: r, article: res.Article}, nil
+}
+
+type articleUpdateInput struct {
+	Title        *string
+	Body         *string
+	Category     *string
+	AuthorID     *graphql.ID
+	Status       *string
+	Slug         *string
+	PublishAt    *dateTime
+	EmbargoUntil *dateTime
+}
+
+// merge returns a copy of cur with the fields set in the input, the fields of the input are reported under path.
+func (in *articleUpdateInput) merge(path string, cur *pb.Article) (*pb.Article, error) {
+	a := proto.Clone(cur).(*pb.Article)
+	if in.Title != nil {
+		a.Title = *in.Title
+	}
+	if in.Body != nil {
+		a.Body = *in.Body
+	}
+	if in.Category != nil {
+		a.Category = *in.Category
+	}
+	if in.AuthorID != nil {
+		authorID, err := unmarshalID(*in.AuthorID, authorKind, path, "author_id")
+		if err != nil {
+			return nil, err
+		}
+		a.AuthorId = authorID
+	}
+	if in.Status != nil {
+		a.Status = pb.ArticleStatus(pb.ArticleStatus_value[*in.Status])
+	}
+	if in.Slug != nil {
+		a.Slug = *in.Slug
+	}
+	var err error
+	if in.PublishAt != nil {
+		if a.PublishAt, err = in.PublishAt.timestamp(path, "publish_at"); err != nil {
+			return nil, err
+		}
+	}
+	if in.EmbargoUntil != nil {
+		if a.EmbargoUntil, err = in.EmbargoUntil.timestamp(path, "embargo_until"); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// UpdateArticle merges the input onto the current article. The update fails with a conflict
+// if the article has been modified since expected_version, so nothing newer is overwritten.
+func (r *queryResolver) UpdateArticle(ctx context.Context, args struct {
+	ID              graphql.ID
+	ExpectedVersion int32
+	Input           *articleUpdateInput
+	Editor          *string
+}) (*articleResolver, error) {
+	aid, err := unmarshalID(args.ID, articleKind, "id")
+	if err != nil {
+		return nil, err
+	}
+	cur, err := r.client.Article(ctx, &pb.ArticleRequest{Id: aid})
+	if err != nil {
+		return nil, translate(err, "failed to update article", "")
+	}
+	a, err := args.Input.merge("input", cur.Article)
+	if err != nil {
+		return nil, err
+	}
+	return r.update(ctx, a, args.ExpectedVersion, optional(args.Editor))
+}
+
+type statusArgs struct {
+	ID              graphql.ID
+	ExpectedVersion int32
+	Reason          *string
+	Editor          *string
+}
+
+// optional returns the value of an optional argument, it is empty if the argument is omitted.
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (r *queryResolver) PublishArticle(ctx context.Context, args statusArgs) (*articleResolver, error) {
+	aid, err := unmarshalID(args.ID, articleKind, "id")
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.PublishArticle(ctx, &pb.PublishArticleRequest{Id: aid, ExpectedVersion: uint32(args.ExpectedVersion), Reason: optional(args.Reason), Editor: optional(args.Editor)})
+	if err != nil {
+		return nil, translate(err, "failed to publish article", "")
+	}
+	return &articleResolver{root: r, article: res.Article}, nil
+}
+
+func (r *queryResolver) RetractArticle(ctx context.Context, args statusArgs) (*articleResolver, error) {
+	aid, err := unmarshalID(args.ID, articleKind, "id")
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.RetractArticle(ctx, &pb.RetractArticleRequest{Id: aid, ExpectedVersion: uint32(args.ExpectedVersion), Reason: optional(args.Reason), Editor: optional(args.Editor)})
+	if err != nil {
+		return nil, translate(err, "failed to retract article", "")
+	}
+	return &articleResolver{root: r, article: res.Article}, nil
+}
+
+func (r *queryResolver) UnpublishArticle(ctx context.Context, args statusArgs) (*articleResolver, error) {
+	aid, err := unmarshalID(args.ID, articleKind, "id")
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.UnpublishArticle(ctx, &pb.UnpublishArticleRequest{Id: aid, ExpectedVersion: uint32(args.ExpectedVersion), Reason: optional(args.Reason), Editor: optional(args.Editor)})
+	if err != nil {
+		return nil, translate(err, "failed to unpublish article", "")
+	}
+	return &articleResolver{root: r, article: res.Article}, nil
+}
+
+func (r *queryResolver) update(ctx context.Context, a *pb.Article, version int32, editor string) (*articleResolver, error) {
+	req := &pb.UpdateArticleRequest{Article: a, ExpectedVersion: uint32(version), Editor: editor}
+	res, err := r.client.UpdateArticle(ctx, req)
+	if err != nil {
+		return nil, translate(err, "failed to update article", "input")
+	}
+
+	return &articleResolver{root: r, article: res.Article}, nil
+}
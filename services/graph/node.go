@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+// Node returns the object with the given global ID or null if it doesn't exist.
+func (r *queryResolver) Node(ctx context.Context, args struct{ ID graphql.ID }) (*nodeResolver, error) {
+	switch relay.UnmarshalKind(args.ID) {
+	case articleKind:
+		id, err := unmarshalID(args.ID, articleKind, "id")
+		if err != nil {
+			return nil, err
+		}
+		a, err := r.article(ctx, id)
+		if err != nil {
+			return nil, translate(err, "failed to get article", "")
+		}
+		if a == nil {
+			return nil, nil
+		}
+		return &nodeResolver{&articleResolver{root: r, article: a}}, nil
+	case authorKind:
+		id, err := unmarshalID(args.ID, authorKind, "id")
+		if err != nil {
+			return nil, err
+		}
+		a, err := r.author(ctx, id)
+		if err != nil {
+			return nil, translate(err, "failed to get author", "")
+		}
+		if a == nil {
+			return nil, nil
+		}
+		return &nodeResolver{&authorResolver{root: r, author: a}}, nil
+	}
+	return nil, invalidID(args.ID, []string{"id"})
+}
+
+type node interface {
+	ID() graphql.ID
+}
+
+type nodeResolver struct {
+	node
+}
+
+func (r *nodeResolver) ToArticle() (*articleResolver, bool) {
+	a, ok := r.node.(*articleResolver)
+	return a, ok
+}
+
+func (r *nodeResolver) ToAuthor() (*authorResolver, bool) {
+	a, ok := r.node.(*authorResolver)
+	return a, ok
+}
+
+// unmarshalID returns the numeric ID of an object of the given kind. Malformed IDs and
+// IDs of other kinds are reported as invalid input under path, the path of the argument.
+func unmarshalID(id graphql.ID, kind string, path ...string) (uint32, error) {
+	var res uint32
+	if relay.UnmarshalKind(id) != kind || relay.UnmarshalSpec(id, &res) != nil {
+		return 0, invalidID(id, path)
+	}
+	return res, nil
+}
+
+func invalidID(id graphql.ID, path []string) error {
+	return &inputError{
+		msg:        fmt.Sprintf("invalid ID %q", id),
+		violations: []violation{{Path: path, Message: "invalid ID"}},
+	}
+}
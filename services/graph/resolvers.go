@@ -7,6 +7,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 
+	"github.com/pavelnikolov/eventsourcing-go/broker"
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
 )
 
@@ -16,21 +17,29 @@ const (
 )
 
 type queryResolver struct {
-	client pb.ArticlesClient
+	client  pb.ArticlesClient
+	authors pb.AuthorsClient
+	events  *broker.Broker
 }
 
 func (r *queryResolver) Article(ctx context.Context, args struct{ ID graphql.ID }) (*articleResolver, error) {
-	var aid int32
-	relay.UnmarshalSpec(args.ID, &aid)
-	a, err := r.client.Article(ctx, &pb.ArticleRequest{Id: uint32(aid)})
+	aid, err := unmarshalID(args.ID, articleKind, "id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get article: %v", err)
+		return nil, err
+	}
+	a, err := r.article(ctx, aid)
+	if err != nil {
+		return nil, translate(err, "failed to get article", "")
+	}
+	if a == nil {
+		return nil, notFound(fmt.Sprintf("failed to get article: article %d not found", aid), articleKind, aid)
 	}
 
-	return &articleResolver{article: a.Article}, nil
+	return &articleResolver{root: r, article: a}, nil
 }
 
 type articleResolver struct {
+	root    *queryResolver
 	article *pb.Article
 }
 
@@ -50,6 +59,10 @@ func (r *articleResolver) Title() string {
 	return r.article.Title
 }
 
+func (r *articleResolver) Slug() string {
+	return r.article.Slug
+}
+
 func (r *articleResolver) AuthorID() graphql.ID {
 	return relay.MarshalID(authorKind, r.article.AuthorId)
 }
@@ -58,10 +71,48 @@ func (r *articleResolver) AuthorName() string {
 	return r.article.AuthorName
 }
 
+// Author is null if the author of the article doesn't exist.
+func (r *articleResolver) Author(ctx context.Context) (*authorResolver, error) {
+	a, err := r.root.author(ctx, r.article.AuthorId)
+	if err != nil {
+		return nil, translate(err, "failed to get author", "")
+	}
+	if a == nil {
+		return nil, nil
+	}
+
+	return &authorResolver{root: r.root, author: a}, nil
+}
+
 func (r *articleResolver) Status() string {
 	return r.article.Status.String()
 }
 
+func (r *articleResolver) Version() int32 {
+	return int32(r.article.Version)
+}
+
+func (r *articleResolver) Created() *dateTime {
+	return newDateTime(r.article.Created)
+}
+
+func (r *articleResolver) Modified() *dateTime {
+	return newDateTime(r.article.Modified)
+}
+
+// PublishedAt is null if the article has never been published.
+func (r *articleResolver) PublishedAt() *dateTime {
+	return newDateTime(r.article.Published)
+}
+
+func (r *articleResolver) PublishAt() *dateTime {
+	return newDateTime(r.article.PublishAt)
+}
+
+func (r *articleResolver) EmbargoUntil() *dateTime {
+	return newDateTime(r.article.EmbargoUntil)
+}
+
 func (r *queryResolver) Articles(ctx context.Context, args struct {
 	Category *string
 	Count    int32
@@ -78,13 +129,171 @@ func (r *queryResolver) Articles(ctx context.Context, args struct {
 	}
 	articles, err := r.client.LatestArticles(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get article: %v", err)
+		return nil, translate(err, "failed to get article", "")
 	}
 
 	var res []*articleResolver
 	for _, a := range articles.Articles {
-		res = append(res, &articleResolver{article: a})
+		res = append(res, &articleResolver{root: r, article: a})
 	}
 
 	return res, nil
 }
+
+func (r *queryResolver) ArticlesConnection(ctx context.Context, args struct {
+	Category *string
+	First    int32
+	After    *string
+	Status   string
+}) (*articleConnectionResolver, error) {
+	req := &pb.LatestArticlesRequest{
+		Count:  uint32(args.First),
+		Status: pb.ArticleStatus(pb.ArticleStatus_value[args.Status]),
+	}
+	if args.Category != nil {
+		req.Category = *args.Category
+	}
+	if args.After != nil {
+		req.PageToken = *args.After
+	}
+	res, err := r.client.LatestArticles(ctx, req)
+	if err != nil {
+		return nil, translate(err, "failed to get articles", "")
+	}
+
+	return &articleConnectionResolver{root: r, res: res}, nil
+}
+
+type articleConnectionResolver struct {
+	root *queryResolver
+	res  *pb.ArticlesReply
+}
+
+func (r *articleConnectionResolver) Edges() []*articleEdgeResolver {
+	var res []*articleEdgeResolver
+	for i, a := range r.res.Articles {
+		res = append(res, &articleEdgeResolver{root: r.root, cursor: r.res.Cursors[i], article: a})
+	}
+	return res
+}
+
+func (r *articleConnectionResolver) PageInfo() *pageInfoResolver {
+	p := &pageInfoResolver{hasNextPage: r.res.NextPageToken != ""}
+	if n := len(r.res.Cursors); n > 0 {
+		p.startCursor, p.endCursor = &r.res.Cursors[0], &r.res.Cursors[n-1]
+	}
+	return p
+}
+
+type articleEdgeResolver struct {
+	root    *queryResolver
+	cursor  string
+	article *pb.Article
+}
+
+func (r *articleEdgeResolver) Cursor() string {
+	return r.cursor
+}
+
+func (r *articleEdgeResolver) Node() *articleResolver {
+	return &articleResolver{root: r.root, article: r.article}
+}
+
+type pageInfoResolver struct {
+	hasNextPage bool
+	startCursor *string
+	endCursor   *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+// HasPreviousPage is always false because the pages can be fetched forward only.
+func (r *pageInfoResolver) HasPreviousPage() bool {
+	return false
+}
+
+func (r *pageInfoResolver) StartCursor() *string {
+	return r.startCursor
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+func (r *queryResolver) Search(ctx context.Context, args struct {
+	Query    string
+	Category *string
+	Status   string
+	Count    int32
+	Offset   int32
+}) (*searchResultResolver, error) {
+	var category string
+	if args.Category != nil {
+		category = *args.Category
+	}
+	req := &pb.SearchArticlesRequest{
+		Query:    args.Query,
+		Category: category,
+		Status:   pb.ArticleStatus(pb.ArticleStatus_value[args.Status]),
+		Count:    uint32(args.Count),
+		Offset:   uint32(args.Offset),
+	}
+	res, err := r.client.SearchArticles(ctx, req)
+	if err != nil {
+		return nil, translate(err, "failed to search articles", "")
+	}
+
+	return &searchResultResolver{root: r, res: res}, nil
+}
+
+type searchResultResolver struct {
+	root *queryResolver
+	res  *pb.SearchArticlesReply
+}
+
+func (r *searchResultResolver) Total() int32 {
+	return int32(r.res.Total)
+}
+
+func (r *searchResultResolver) Hits() []*searchHitResolver {
+	var res []*searchHitResolver
+	for _, h := range r.res.Hits {
+		res = append(res, &searchHitResolver{root: r.root, hit: h})
+	}
+	return res
+}
+
+type searchHitResolver struct {
+	root *queryResolver
+	hit  *pb.SearchHit
+}
+
+func (r *searchHitResolver) Article() *articleResolver {
+	return &articleResolver{root: r.root, article: r.hit.Article}
+}
+
+func (r *searchHitResolver) Score() float64 {
+	return r.hit.Score
+}
+
+func (r *searchHitResolver) Snippets() []*snippetResolver {
+	var res []*snippetResolver
+	for _, s := range r.hit.Snippets {
+		res = append(res, &snippetResolver{snippet: s})
+	}
+	return res
+}
+
+type snippetResolver struct {
+	snippet *pb.Snippet
+}
+
+func (r *snippetResolver) Field() string {
+	return r.snippet.Field
+}
+
+func (r *snippetResolver) Fragments() []string {
+	return r.snippet.Fragments
+}
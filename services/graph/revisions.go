@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+type revisionResolver struct {
+	root     *queryResolver
+	revision *pb.ArticleRevision
+}
+
+func (r *revisionResolver) Version() int32 {
+	return int32(r.revision.Version)
+}
+
+func (r *revisionResolver) Article() *articleResolver {
+	return &articleResolver{root: r.root, article: r.revision.Article}
+}
+
+func (r *revisionResolver) Editor() string {
+	return r.revision.Editor
+}
+
+func (r *revisionResolver) Reason() string {
+	return r.revision.Reason
+}
+
+func (r *revisionResolver) Created() *dateTime {
+	return newDateTime(r.revision.Created)
+}
+
+func (r *revisionResolver) Changes() []*fieldChangeResolver {
+	res := make([]*fieldChangeResolver, len(r.revision.Changes))
+	for i, c := range r.revision.Changes {
+		res[i] = &fieldChangeResolver{c}
+	}
+	return res
+}
+
+// RevertedVersion is null unless the revision is a revert.
+func (r *revisionResolver) RevertedVersion() *int32 {
+	if r.revision.RevertedVersion == 0 {
+		return nil
+	}
+	v := int32(r.revision.RevertedVersion)
+	return &v
+}
+
+type fieldChangeResolver struct {
+	change *pb.FieldChange
+}
+
+func (r *fieldChangeResolver) Field() string {
+	return r.change.Field
+}
+
+func (r *fieldChangeResolver) PreviousValue() string {
+	return r.change.PreviousValue
+}
+
+func (r *fieldChangeResolver) Value() string {
+	return r.change.Value
+}
+
+// Revisions lists the revisions of the article, newest first.
+func (r *articleResolver) Revisions(ctx context.Context) ([]*revisionResolver, error) {
+	res, err := r.root.client.ListArticleRevisions(ctx, &pb.ListArticleRevisionsRequest{Id: r.article.Id})
+	if err != nil {
+		return nil, translate(err, "failed to get revisions", "")
+	}
+
+	revs := make([]*revisionResolver, len(res.Revisions))
+	for i, rev := range res.Revisions {
+		revs[i] = &revisionResolver{root: r.root, revision: rev}
+	}
+	return revs, nil
+}
+
+// Revision is null if the article has no revision with the given version.
+func (r *articleResolver) Revision(ctx context.Context, args struct{ Version int32 }) (*revisionResolver, error) {
+	res, err := r.root.client.GetArticleRevision(ctx, &pb.GetArticleRevisionRequest{Id: r.article.Id, Version: uint32(args.Version)})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, translate(err, "failed to get revision", "")
+	}
+	return &revisionResolver{root: r.root, revision: res.Revision}, nil
+}
+
+func (r *queryResolver) RevertArticle(ctx context.Context, args struct {
+	ID              graphql.ID
+	Version         int32
+	ExpectedVersion int32
+	Editor          *string
+}) (*articleResolver, error) {
+	aid, err := unmarshalID(args.ID, articleKind, "id")
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.RevertArticleRequest{Id: aid, Version: uint32(args.Version), ExpectedVersion: uint32(args.ExpectedVersion), Editor: optional(args.Editor)}
+	res, err := r.client.RevertArticle(ctx, req)
+	if err != nil {
+		return nil, translate(err, "failed to revert article", "")
+	}
+
+	return &articleResolver{root: r, article: res.Article}, nil
+}
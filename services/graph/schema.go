@@ -4,14 +4,91 @@ package graph
 var Schema = `
 	schema {
 		query: Query
+		mutation: Mutation
+		subscription: Subscription
 	}
 	
 	# The query type, represents all of the entry points into our object graph
+	# The objects which don't exist are reported with the "NOT_FOUND" code and the global id of the object in the
+	# "resource" of the error extensions. The errors which may go away on retry are reported with the "UNAVAILABLE" code.
 	type Query {
 		# article queries for an article by the provided id.
 		article(id: ID!): Article
 		# articles queries for latest artciles by category and status. If category is not provided it returns latest articles from all categories. 
 		articles(category: String, count: Int! = 10, status: ArticleStatus! = PUBLISHED): [Article]!
+		# articlesConnection pages through the latest articles, newest first. The pages stay stable while new articles are created.
+		articlesConnection(category: String, first: Int! = 10, after: String, status: ArticleStatus! = PUBLISHED): ArticleConnection!
+		# author queries for an author by the provided id.
+		author(id: ID!): Author
+		# node queries for any object by its global id. It is null if the object doesn't exist.
+		node(id: ID!): Node
+		# search performs a full-text search of the articles. The query supports the Bleve query string syntax.
+		search(query: String!, category: String, status: ArticleStatus! = PUBLISHED, count: Int! = 10, offset: Int! = 0): SearchResult!
+	}
+
+	# The mutation type, represents all updates we can make to our data.
+	# Invalid input is reported with the "BAD_USER_INPUT" code and the paths of the invalid fields in the error extensions.
+	# The changes which the editorial policy doesn't allow, e.g. editing a retracted article, are reported with the "FAILED_PRECONDITION" code.
+	# The conflicts and the changes of articles which don't exist carry the global id of the article in the "resource" of the error extensions.
+	# The editor of every mutation is recorded in the revision history of the article.
+	type Mutation {
+		# createArticle creates an article with the next free id. The retries with the same idempotency_key
+		# get the article created by the first attempt instead of creating another one, reusing the key
+		# for a different article is reported with the "CONFLICT" code.
+		createArticle(input: ArticleInput!, idempotency_key: String, editor: String): Article
+		# updateArticle changes the fields of an article set in the input unless it has been modified since expected_version.
+		updateArticle(id: ID!, expected_version: Int!, input: ArticleUpdateInput!, editor: String): Article
+		# publishArticle changes the status of an article to PUBLISHED.
+		publishArticle(id: ID!, expected_version: Int!, reason: String, editor: String): Article
+		# retractArticle changes the status of a published article to RETRACTED.
+		retractArticle(id: ID!, expected_version: Int!, reason: String, editor: String): Article
+		# unpublishArticle changes the status of a published article back to DRAFT.
+		unpublishArticle(id: ID!, expected_version: Int!, reason: String, editor: String): Article
+		# revertArticle restores the content of the revision with the given version of an article. The status of the article is kept.
+		revertArticle(id: ID!, version: Int!, expected_version: Int!, editor: String): Article
+	}
+
+	# The subscription type, served over WebSockets using the graphql-ws protocol.
+	type Subscription {
+		# articlePublished notifies about the articles as they are published. If category is not provided it notifies about all categories.
+		articlePublished(category: String): Article!
+		# articleChanged sends the new state of an article every time it changes.
+		articleChanged(id: ID!): Article!
+	}
+
+	# ArticleInput is a new article. The article shows the current name of the author with author_id.
+	input ArticleInput {
+		title: String!
+		body: String!
+		category: String!
+		author_id: ID!
+		status: ArticleStatus! = DRAFT
+		# slug is generated from the title when the article is created and kept when it is omitted
+		slug: String
+		# publish_at is required for the SCHEDULED articles, they are published automatically at that time
+		publish_at: DateTime
+		# embargo_until keeps a published article out of the listings, the feeds and the sitemaps until it passes
+		embargo_until: DateTime
+	}
+
+	# ArticleUpdateInput changes the fields which are set, the omitted fields keep their current values.
+	input ArticleUpdateInput {
+		title: String
+		body: String
+		category: String
+		author_id: ID
+		status: ArticleStatus
+		slug: String
+		publish_at: DateTime
+		embargo_until: DateTime
+	}
+
+	# DateTime is an RFC 3339 timestamp in UTC, e.g. "2018-05-01T10:00:00Z".
+	scalar DateTime
+
+	# Node is an object with a global id.
+	interface Node {
+		id: ID!
 	}
 
 	enum ArticleStatus {
@@ -19,15 +96,105 @@ var Schema = `
 		DRAFT
 		PUBLISHED
 		RETRACTED
+		SCHEDULED
 	}
 
-	type Article {
+	type Article implements Node {
 		id: ID!
 		title: String!
+		# slug is the part of the URL of the article, it stays the same when the title changes
+		slug: String!
 		body: String!
 		category: String!
 		author_id: ID!
 		author_name: String!
+		# author is null if the author doesn't exist
+		author: Author
 		status: ArticleStatus!
+		# version has to be sent back when the article is updated
+		version: Int!
+		created: DateTime
+		modified: DateTime
+		# published_at is when the article was last published, it is null if it has never been published
+		published_at: DateTime
+		# publish_at is when a SCHEDULED article is going to be published
+		publish_at: DateTime
+		# embargo_until is when the article shows up in the listings, the feeds and the sitemaps
+		embargo_until: DateTime
+		# revisions lists the changes of the article, newest first.
+		revisions: [Revision!]!
+		# revision is the revision after which the article had the given version, it is null if there is no such revision
+		revision(version: Int!): Revision
+	}
+
+	# Revision is a change of an article made by a single request.
+	type Revision {
+		# version is the version of the article after the revision
+		version: Int!
+		# article is the state of the article after the revision
+		article: Article!
+		# editor is who made the change, it is empty if unknown
+		editor: String!
+		# reason is why the change was made, it is empty if unknown
+		reason: String!
+		created: DateTime
+		# changes are the fields which differ from the previous revision
+		changes: [FieldChange!]!
+		# reverted_version is the version restored by the revision, it is null unless the revision is a revert
+		reverted_version: Int
+	}
+
+	# FieldChange is a change of a single field of an article.
+	type FieldChange {
+		field: String!
+		previous_value: String!
+		value: String!
+	}
+
+	type Author implements Node {
+		id: ID!
+		name: String!
+		bio: String!
+		email: String!
+		avatar_url: String!
+		version: Int!
+		# articles pages through the latest articles of the author, newest first.
+		articles(first: Int! = 10, after: String, status: ArticleStatus! = PUBLISHED): ArticleConnection!
+	}
+
+	type ArticleConnection {
+		edges: [ArticleEdge!]!
+		pageInfo: PageInfo!
+	}
+
+	type ArticleEdge {
+		# cursor is passed as after to get the articles following this one
+		cursor: String!
+		node: Article!
+	}
+
+	type PageInfo {
+		hasNextPage: Boolean!
+		hasPreviousPage: Boolean!
+		startCursor: String
+		endCursor: String
+	}
+
+	type SearchResult {
+		# total is the number of all matching articles
+		total: Int!
+		hits: [SearchHit!]!
+	}
+
+	type SearchHit {
+		article: Article!
+		score: Float!
+		snippets: [Snippet!]!
+	}
+
+	# Snippet contains the fragments of a field with the matched terms highlighted.
+	type Snippet {
+		field: String!
+		fragments: [String!]!
 	}
 `
@@ -4,22 +4,31 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/gorilla/websocket"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 
+	"github.com/pavelnikolov/eventsourcing-go/broker"
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
 )
 
 const port = "4001"
 
-// StartServer starts the GraphQL server
-func StartServer(c pb.ArticlesClient) {
-	schema := graphql.MustParseSchema(Schema, &queryResolver{client: c})
+// StartServer starts the GraphQL server. The subscriptions are fed by the events published to b.
+func StartServer(c pb.ArticlesClient, a pb.AuthorsClient, b *broker.Broker) {
+	schema := graphql.MustParseSchema(Schema, &queryResolver{client: c, authors: a, events: b})
 	http.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write(page)
 	}))
 
-	http.Handle("/graphql", &relay.Handler{Schema: schema})
+	h, ws := withLoaders(&relay.Handler{Schema: schema}, c, a), &wsHandler{schema: schema}
+	http.Handle("/graphql", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
 
 	log.Printf("Listening for connections on http://localhost:%s\n", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
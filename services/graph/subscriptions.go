@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"context"
+	"log"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/pavelnikolov/eventsourcing-go/broker"
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// subscriptionBufferSize is the number of events buffered for a subscriber.
+// Subscribers which fall further behind are disconnected so they don't hold up the others.
+const subscriptionBufferSize = 16
+
+func (r *queryResolver) ArticlePublished(ctx context.Context, args struct{ Category *string }) <-chan *articleResolver {
+	f := broker.Filter{Types: []string{proto.MessageName(&pb.ArticlePublished{})}}
+	if args.Category != nil {
+		f.Categories = []string{*args.Category}
+	}
+	events := r.events.Subscribe(ctx, f, subscriptionBufferSize, broker.Disconnect)
+
+	res := make(chan *articleResolver)
+	go func() {
+		defer close(res)
+		for e := range events {
+			select {
+			case res <- &articleResolver{root: r, article: e.GetArticlePublished().Article}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res
+}
+
+func (r *queryResolver) ArticleChanged(ctx context.Context, args struct{ ID graphql.ID }) (<-chan *articleResolver, error) {
+	aid, err := unmarshalID(args.ID, articleKind, "id")
+	if err != nil {
+		return nil, err
+	}
+	f := broker.Filter{AggregateType: proto.MessageName(&pb.Article{}), Aggregates: []uint32{aid}}
+	events := r.events.Subscribe(ctx, f, subscriptionBufferSize, broker.Disconnect)
+
+	res := make(chan *articleResolver)
+	go func() {
+		defer close(res)
+		var version uint32
+		for e := range events {
+			// a single change may consist of several events, the article is sent once per change
+			if e.AggregateVersion <= version {
+				continue
+			}
+			a, err := r.client.Article(ctx, &pb.ArticleRequest{Id: aid})
+			if err != nil {
+				log.Printf("failed to get article %d: %v\n", aid, err)
+				continue
+			}
+			if a.Article.Version <= version {
+				continue
+			}
+			version = a.Article.Version
+
+			select {
+			case res <- &articleResolver{root: r, article: a.Article}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res, nil
+}
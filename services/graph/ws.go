@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// keepAliveInterval is how often the keep alive messages are sent to the clients.
+const keepAliveInterval = 10 * time.Second
+
+// message types of the graphql-ws protocol,
+// see https://github.com/apollographql/subscriptions-transport-ws/blob/master/PROTOCOL.md
+const (
+	gqlConnectionInit      = "connection_init"
+	gqlConnectionAck       = "connection_ack"
+	gqlConnectionKeepAlive = "ka"
+	gqlConnectionTerminate = "connection_terminate"
+	gqlStart               = "start"
+	gqlStop                = "stop"
+	gqlData                = "data"
+	gqlError               = "error"
+	gqlComplete            = "complete"
+)
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type startPayload struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+var upgrader = websocket.Upgrader{Subprotocols: []string{"graphql-ws"}}
+
+// wsHandler serves GraphQL operations, including subscriptions, over WebSockets using the graphql-ws protocol.
+type wsHandler struct {
+	schema *graphql.Schema
+}
+
+func (h *wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("failed to upgrade connection: %v\n", err)
+		return
+	}
+	c := &wsConn{conn: conn, ops: make(map[string]*wsOp)}
+	c.serve(h.schema)
+}
+
+// wsConn is a single WebSocket connection which multiplexes many operations.
+type wsConn struct {
+	conn *websocket.Conn
+	// wmu serialises the writes to the connection
+	wmu sync.Mutex
+
+	// ops are the running operations by ID
+	ops map[string]*wsOp
+	mu  sync.Mutex
+}
+
+// wsOp is a running operation. The clients may reuse the ID of a stopped operation,
+// so the operations are told apart by their pointers.
+type wsOp struct {
+	cancel context.CancelFunc
+}
+
+// serve reads the messages of the client until it disconnects. All running operations are stopped afterwards.
+func (c *wsConn) serve(schema *graphql.Schema) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		c.conn.Close()
+	}()
+
+	var initialised bool
+	for {
+		var msg wsMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("failed to read message: %v\n", err)
+			}
+			return
+		}
+
+		switch msg.Type {
+		case gqlConnectionInit:
+			c.send(wsMessage{Type: gqlConnectionAck})
+			if !initialised {
+				initialised = true
+				go c.keepAlive(ctx)
+			}
+		case gqlStart:
+			if !initialised {
+				c.sendError(msg.ID, "connection has not been initialised")
+				continue
+			}
+			var p startPayload
+			if err := json.Unmarshal(msg.Payload, &p); err != nil {
+				c.sendError(msg.ID, "invalid payload")
+				continue
+			}
+			c.start(ctx, schema, msg.ID, p)
+		case gqlStop:
+			c.stop(msg.ID)
+		case gqlConnectionTerminate:
+			return
+		default:
+			c.sendError(msg.ID, "unknown message type "+msg.Type)
+		}
+	}
+}
+
+func (c *wsConn) start(ctx context.Context, schema *graphql.Schema, id string, p startPayload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.ops[id]; ok {
+		c.sendError(id, "operation "+id+" is already running")
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	op := &wsOp{cancel: cancel}
+	c.ops[id] = op
+
+	responses, err := schema.Subscribe(ctx, p.Query, p.OperationName, p.Variables)
+	if err != nil {
+		delete(c.ops, id)
+		cancel()
+		c.sendError(id, err.Error())
+		return
+	}
+	go func() {
+		for res := range responses {
+			data, err := json.Marshal(res)
+			if err != nil {
+				log.Printf("failed to marshal response: %v\n", err)
+				continue
+			}
+			c.send(wsMessage{ID: id, Type: gqlData, Payload: data})
+		}
+		// the operations stopped by the client are complete already
+		if c.finish(id, op) {
+			c.send(wsMessage{ID: id, Type: gqlComplete})
+		}
+	}()
+}
+
+func (c *wsConn) stop(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if op, ok := c.ops[id]; ok {
+		op.cancel()
+		delete(c.ops, id)
+	}
+}
+
+// finish removes the operation which has ended unless it has been stopped already.
+// It reports whether the operation was still running.
+func (c *wsConn) finish(id string, op *wsOp) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	op.cancel()
+	if c.ops[id] != op {
+		return false
+	}
+	delete(c.ops, id)
+	return true
+}
+
+func (c *wsConn) keepAlive(ctx context.Context) {
+	t := time.NewTicker(keepAliveInterval)
+	defer t.Stop()
+	for {
+		c.send(wsMessage{Type: gqlConnectionKeepAlive})
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *wsConn) sendError(id, msg string) {
+	payload, _ := json.Marshal(map[string]string{"message": msg})
+	c.send(wsMessage{ID: id, Type: gqlError, Payload: payload})
+}
+
+// send ignores the errors, a broken connection is detected by the reader.
+func (c *wsConn) send(msg wsMessage) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	c.conn.WriteJSON(msg)
+}
@@ -0,0 +1,108 @@
+package redirect
+
+import (
+	"log"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pavelnikolov/eventsourcing-go/eventstream"
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// Projection is the read model of the redirects. It keeps what the canonical
+// URLs are made of for every published article up to date from the article events.
+type Projection struct {
+	articles map[uint32]*pb.Article
+	// position is the position of the last applied event
+	position uint64
+	// logID is the ID of the first event of the log the position refers to
+	logID string
+	sync.RWMutex
+}
+
+// NewProjection returns an empty projection.
+func NewProjection() *Projection {
+	return &Projection{articles: make(map[uint32]*pb.Article)}
+}
+
+// Run keeps the projection up to date with the events of the articles service until ctx is done.
+// It replays the events from the beginning and starts over if the event log has been replaced
+// in the meantime, e.g. because it has been wiped, or if the projection is ahead of it.
+func (p *Projection) Run(ctx context.Context, c pb.ArticlesClient) error {
+	for {
+		p.RLock()
+		req := &pb.SubscribeEventsRequest{Start: pb.SubscribeEventsRequest_AFTER, Position: p.position, LogId: p.logID}
+		p.RUnlock()
+
+		err := eventstream.Consume(ctx, c, req, p.apply, nil)
+		switch status.Code(err) {
+		case codes.FailedPrecondition, codes.OutOfRange:
+		default:
+			return err
+		}
+		log.Printf("the redirects don't match the event log, rebuilding: %v\n", err)
+		p.Lock()
+		p.articles, p.position, p.logID = make(map[uint32]*pb.Article), 0, ""
+		p.Unlock()
+	}
+}
+
+// Article returns the published article with the given id.
+func (p *Projection) Article(id uint32) (*pb.Article, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	a, ok := p.articles[id]
+	return a, ok
+}
+
+func (p *Projection) apply(ctx context.Context, e *pb.Event) error {
+	p.Lock()
+	defer p.Unlock()
+	// the projection is built from the beginning of the log, so the first event identifies it
+	if p.position == 0 {
+		p.logID = e.Id
+	}
+
+	switch pl := e.Payload.(type) {
+	case *pb.Event_ArticleCreated:
+		p.put(pl.ArticleCreated.Article)
+	case *pb.Event_ArticleUpdated:
+		p.put(pl.ArticleUpdated.Article)
+	case *pb.Event_ArticleDrafted:
+		p.put(pl.ArticleDrafted.Article)
+	case *pb.Event_ArticlePublished:
+		p.put(pl.ArticlePublished.Article)
+	case *pb.Event_ArticleRetracted:
+		p.put(pl.ArticleRetracted.Article)
+	case *pb.Event_ArticleScheduled:
+		p.put(pl.ArticleScheduled.Article)
+	case *pb.Event_ArticleRetitled:
+		if a, ok := p.articles[e.AggregateId]; ok {
+			a = proto.Clone(a).(*pb.Article)
+			a.Title = pl.ArticleRetitled.Title
+			p.articles[e.AggregateId] = a
+		}
+	case *pb.Event_ArticleRecategorised:
+		if a, ok := p.articles[e.AggregateId]; ok {
+			a = proto.Clone(a).(*pb.Article)
+			a.Category = pl.ArticleRecategorised.Category
+			p.articles[e.AggregateId] = a
+		}
+	}
+	p.position = e.Position
+	return nil
+}
+
+// put must be called while holding the write lock. Only the published articles are
+// kept, the body is dropped because the URLs don't depend on it.
+func (p *Projection) put(a *pb.Article) {
+	if a.Status != pb.ArticleStatus_PUBLISHED {
+		delete(p.articles, a.Id)
+		return
+	}
+	p.articles[a.Id] = &pb.Article{Id: a.Id, Title: a.Title, Slug: a.Slug, Category: a.Category, Status: a.Status}
+}
@@ -0,0 +1,37 @@
+package redirect
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/pavelnikolov/eventsourcing-go/urls"
+)
+
+const port = "4004"
+
+// StartServer starts http server which redirects the legacy and outdated URLs of the
+// published articles, e.g. the ones with the slug of an old title, to their canonical URLs on host.
+func StartServer(p *Projection, host string) {
+	http.Handle("/", redirectHandler(p, host))
+
+	log.Printf("Listening for connections on http://localhost:%s\n", port)
+	log.Fatal(http.ListenAndServe(":"+port, nil))
+}
+
+// redirectHandler resolves the article by the ID at the end of the path and
+// redirects permanently to its canonical URL, keeping the query string.
+func redirectHandler(p *Projection, host string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a, ok := p.Article(urls.ID(r.URL.Path))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		u := urls.Article(host, a)
+		if r.URL.RawQuery != "" {
+			u += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, u, http.StatusMovedPermanently)
+	}
+}
@@ -0,0 +1,66 @@
+package rss
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// document is a rendered feed which is served until the feeds change.
+type document struct {
+	content  []byte
+	etag     string
+	modified time.Time
+}
+
+// serve responds with 304 Not Modified if the client has the current version of the document.
+func (d *document) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("ETag", d.etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, "", d.modified, bytes.NewReader(d.content))
+}
+
+// cache keeps the rendered feeds until the next change of the projection or the end of an embargo.
+type cache struct {
+	p        *Projection
+	revision uint64
+	// lifted is the time the last embargo ended when the documents were rendered
+	lifted time.Time
+	docs   map[string]*document
+	mu     sync.Mutex
+}
+
+func newCache(p *Projection) *cache {
+	return &cache{p: p, docs: make(map[string]*document)}
+}
+
+// get returns the document cached under key or renders it if the feeds have changed since.
+func (c *cache) get(key string, now time.Time, render func() ([]byte, error)) (*document, error) {
+	revision, modified := c.p.Revision()
+	lifted := c.p.Lifted(now)
+	if lifted.After(modified) {
+		modified = lifted
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if revision != c.revision || !lifted.Equal(c.lifted) {
+		c.revision, c.lifted = revision, lifted
+		c.docs = make(map[string]*document)
+	}
+	if d, ok := c.docs[key]; ok {
+		return d, nil
+	}
+
+	content, err := render()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha1.Sum(content)
+	d := &document{content: content, etag: `"` + hex.EncodeToString(sum[:]) + `"`, modified: modified}
+	c.docs[key] = d
+	return d, nil
+}
@@ -0,0 +1,196 @@
+package rss
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/feeds"
+)
+
+// format is an output format of the feeds.
+type format struct {
+	// ext is the path suffix which selects the format, e.g. "/feed.atom"
+	ext         string
+	contentType string
+	write       func(w io.Writer, f *feed) error
+}
+
+// formats are in the order of preference, the first one is the default.
+var formats = []format{
+	{ext: "", contentType: "application/rss+xml", write: writeRss},
+	{ext: ".atom", contentType: "application/atom+xml", write: writeAtom},
+	{ext: ".json", contentType: "application/feed+json", write: writeJSON},
+}
+
+// feed is the generic feed together with what the generic items cannot hold.
+type feed struct {
+	*feeds.Feed
+	// categories are the categories of the items
+	categories []string
+}
+
+// formatOf selects the format by the path suffix or, when there is none, by the Accept header.
+func formatOf(urlPath, accept string) format {
+	ext := path.Ext(urlPath)
+	for _, f := range formats {
+		if f.ext != "" && f.ext == ext {
+			return f
+		}
+	}
+
+	best, bestQ := formats[0], 0.0
+	for _, r := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(r))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		for _, f := range formats {
+			if q > bestQ && accepts(mediaType, f.contentType) {
+				best, bestQ = f, q
+			}
+		}
+	}
+	return best
+}
+
+// accepts reports whether the media range of the Accept header matches the content type.
+// The generic XML and JSON types match Atom and JSON Feed respectively.
+func accepts(mediaRange, contentType string) bool {
+	switch mediaRange {
+	case contentType:
+		return true
+	case "application/xml", "text/xml":
+		return contentType == "application/atom+xml"
+	case "application/json":
+		return contentType == "application/feed+json"
+	}
+	return false
+}
+
+func writeRss(w io.Writer, f *feed) error {
+	rss := (&feeds.Rss{Feed: f.Feed}).RssFeed()
+	for i, item := range rss.Items {
+		item.Category = f.categories[i]
+		// RSS expects the email address of the author, the authors without one are left out
+		item.Author = ""
+		if a := f.Items[i].Author; a != nil && a.Email != "" {
+			item.Author = a.Email + " (" + a.Name + ")"
+		}
+	}
+	return feeds.WriteXML(rss, w)
+}
+
+// atomFeed adds the categories the generic Atom entries lack.
+type atomFeed struct {
+	*feeds.AtomFeed
+	Items []*atomEntry
+}
+
+type atomEntry struct {
+	*feeds.AtomEntry
+	Categories []atomCategory
+}
+
+type atomCategory struct {
+	XMLName xml.Name `xml:"category"`
+	Term    string   `xml:"term,attr"`
+}
+
+func (f *atomFeed) FeedXml() interface{} {
+	return f
+}
+
+func writeAtom(w io.Writer, f *feed) error {
+	atom := &atomFeed{AtomFeed: (&feeds.Atom{Feed: f.Feed}).AtomFeed()}
+	atom.Id = f.Id
+	for i, e := range atom.Entries {
+		e.Published = f.Items[i].Created.Format(time.RFC3339)
+		atom.Items = append(atom.Items, &atomEntry{AtomEntry: e, Categories: []atomCategory{{Term: f.categories[i]}}})
+	}
+	atom.Entries = nil
+	return feeds.WriteXML(atom, w)
+}
+
+// jsonFeedVersion is the JSON Feed version, the feeds package supports only version 1.
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string        `json:"version"`
+	Title       string        `json:"title"`
+	HomePageURL string        `json:"home_page_url,omitempty"`
+	FeedURL     string        `json:"feed_url,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Authors     []*jsonAuthor `json:"authors,omitempty"`
+	Language    string        `json:"language,omitempty"`
+	Items       []*jsonItem   `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonItem struct {
+	ID            string        `json:"id"`
+	URL           string        `json:"url,omitempty"`
+	Title         string        `json:"title,omitempty"`
+	ContentText   string        `json:"content_text"`
+	Summary       string        `json:"summary,omitempty"`
+	DatePublished *time.Time    `json:"date_published,omitempty"`
+	DateModified  *time.Time    `json:"date_modified,omitempty"`
+	Authors       []*jsonAuthor `json:"authors,omitempty"`
+	Tags          []string      `json:"tags,omitempty"`
+}
+
+func writeJSON(w io.Writer, f *feed) error {
+	res := &jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link.Href,
+		Description: f.Description,
+		Language:    "en",
+		Items:       []*jsonItem{},
+	}
+	if f.Author != nil {
+		res.Authors = []*jsonAuthor{{Name: f.Author.Name, URL: "mailto:" + f.Author.Email}}
+	}
+	for i, item := range f.Items {
+		ji := &jsonItem{
+			ID:          item.Id,
+			URL:         item.Link.Href,
+			Title:       item.Title,
+			ContentText: item.Content,
+			Summary:     item.Description,
+			Tags:        []string{f.categories[i]},
+		}
+		if !item.Created.IsZero() {
+			ji.DatePublished = &item.Created
+		}
+		if !item.Updated.IsZero() {
+			ji.DateModified = &item.Updated
+		}
+		if item.Author != nil {
+			ji.Authors = []*jsonAuthor{{Name: item.Author.Name}}
+			if item.Author.Email != "" {
+				ji.Authors[0].URL = "mailto:" + item.Author.Email
+			}
+		}
+		res.Items = append(res.Items, ji)
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(res)
+}
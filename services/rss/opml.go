@@ -0,0 +1,83 @@
+package rss
+
+import (
+	"encoding/xml"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pavelnikolov/eventsourcing-go/eventstream"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
+)
+
+type opml struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
+}
+
+type opmlHead struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated"`
+}
+
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlOutline struct {
+	Type    string `xml:"type,attr"`
+	Text    string `xml:"text,attr"`
+	Title   string `xml:"title,attr"`
+	XMLURL  string `xml:"xmlUrl,attr"`
+	HTMLURL string `xml:"htmlUrl,attr"`
+}
+
+// opmlHandler lists the RSS feeds of all categories with published articles, so feed readers
+// can subscribe to all of them at once.
+func opmlHandler(p *Projection, host string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := p.Err(); err != nil {
+			eventstream.Error(w, err)
+			log.Printf("failed to serve feed list: %v\n", err)
+			return
+		}
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		base := scheme + "://" + r.Host
+
+		res := opml{
+			Version: "2.0",
+			Head:    opmlHead{Title: "Company Name Here", DateCreated: time.Now().Format(time.RFC1123Z)},
+		}
+		res.Body.Outlines = append(res.Body.Outlines, opmlOutline{
+			Type:    "rss",
+			Text:    "Latest news",
+			Title:   "Latest news",
+			XMLURL:  base + feedPath(""),
+			HTMLURL: host,
+		})
+		for _, c := range p.Categories(time.Now()) {
+			title := "Latest " + c + " news"
+			res.Body.Outlines = append(res.Body.Outlines, opmlOutline{
+				Type:    "rss",
+				Text:    title,
+				Title:   title,
+				XMLURL:  base + feedPath(c),
+				HTMLURL: urls.Category(host, c),
+			})
+		}
+
+		w.Header().Add("Content-Type", "text/x-opml; charset=utf-8")
+		w.Write([]byte(strings.TrimSuffix(xml.Header, "\n")))
+		e := xml.NewEncoder(w)
+		e.Indent("", "  ")
+		if err := e.Encode(res); err != nil {
+			log.Printf("failed to write OPML: %v\n", err)
+		}
+	}
+}
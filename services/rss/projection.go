@@ -0,0 +1,382 @@
+package rss
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pavelnikolov/eventsourcing-go/eventstream"
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/services/articles"
+)
+
+// checkpointInterval is how often the projection is saved while events are flowing.
+const checkpointInterval = 5 * time.Second
+
+// checkpoint is the saved state of the projection.
+type checkpoint struct {
+	Position uint64                 `json:"position"`
+	LogID    string                 `json:"log_id"`
+	Articles map[uint32]*pb.Article `json:"articles"`
+	Authors  map[uint32]string      `json:"authors"`
+	Emails   map[uint32]string      `json:"emails"`
+	Modified time.Time              `json:"modified"`
+}
+
+// Projection is the read model of the feeds. It keeps the published articles
+// up to date from the article events so the feeds don't depend on the
+// availability of the articles service.
+type Projection struct {
+	// path is the checkpoint file, no checkpoints are saved when empty
+	path string
+
+	position uint64
+	// logID is the ID of the first event of the log the position refers to
+	logID    string
+	articles map[uint32]*pb.Article
+	// authors are the current names of the authors by ID
+	authors map[uint32]string
+	// emails are the email addresses of the authors by ID, if they have one
+	emails map[uint32]string
+	// revision is incremented by every change of the feeds, modified is the time of the last change
+	revision uint64
+	modified time.Time
+	// dirty is set when the state has changed since the last checkpoint
+	dirty bool
+	// rebuild cancels the running consumer so that it starts over from the beginning
+	rebuild context.CancelFunc
+	// stream is the state of the event stream
+	stream eventstream.Status
+	sync.RWMutex
+}
+
+// NewProjection restores the projection from the checkpoint at path, if there is one.
+func NewProjection(path string) (*Projection, error) {
+	p := &Projection{path: path, articles: make(map[uint32]*pb.Article), authors: make(map[uint32]string), emails: make(map[uint32]string)}
+	if path == "" {
+		return p, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	p.position, p.logID = c.Position, c.LogID
+	if c.Articles != nil {
+		p.articles = c.Articles
+	}
+	if c.Authors != nil {
+		p.authors = c.Authors
+	}
+	if c.Emails != nil {
+		p.emails = c.Emails
+	}
+	p.modified = c.Modified
+	return p, nil
+}
+
+// Run keeps the projection up to date with the events of the articles service until ctx is done.
+// When the event log has been replaced since the checkpoint, e.g. because it has been wiped, or the
+// checkpoint is ahead of it, the projection is rebuilt from scratch.
+func (p *Projection) Run(ctx context.Context, c pb.ArticlesClient) error {
+	go p.checkpoints(ctx)
+	defer p.save()
+
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+		p.Lock()
+		p.rebuild = cancel
+		req := &pb.SubscribeEventsRequest{Start: pb.SubscribeEventsRequest_AFTER, Position: p.position, LogId: p.logID}
+		p.Unlock()
+
+		err := eventstream.Consume(runCtx, c, req, p.apply, &p.stream)
+		rebuilding := runCtx.Err() != nil
+		cancel()
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case rebuilding:
+			log.Println("rebuilding the feeds")
+		case status.Code(err) == codes.FailedPrecondition, status.Code(err) == codes.OutOfRange:
+			log.Printf("the feeds don't match the event log, rebuilding: %v\n", err)
+			p.Lock()
+			p.reset()
+			p.Unlock()
+		default:
+			return err
+		}
+	}
+}
+
+// Err returns the error of the event stream while the projection has nothing to serve, e.g. because the
+// articles service has been unreachable since the start. Otherwise the feeds are served even if they are behind.
+func (p *Projection) Err() error {
+	p.RLock()
+	defer p.RUnlock()
+
+	if p.position > 0 {
+		return nil
+	}
+	return p.stream.Err()
+}
+
+// Rebuild discards the state of the projection and replays all events from the beginning.
+func (p *Projection) Rebuild() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.reset()
+	if p.rebuild != nil {
+		p.rebuild()
+	}
+}
+
+// Latest returns the most recently created published articles in category,
+// or in all categories if category is empty. The articles under embargo at now are left out.
+func (p *Projection) Latest(category string, count int, now time.Time) []*pb.Article {
+	p.RLock()
+	defer p.RUnlock()
+
+	var res []*pb.Article
+	for _, a := range p.articles {
+		if (category == "" || a.Category == category) && !articles.Embargoed(a, now) {
+			res = append(res, a)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		ci, cj := res[i].Created.GetSeconds(), res[j].Created.GetSeconds()
+		if ci != cj {
+			return ci > cj
+		}
+		return res[i].Id > res[j].Id
+	})
+	if len(res) > count {
+		res = res[:count]
+	}
+	return res
+}
+
+// Revision returns a number which changes with every change of the feeds and the time of the last change.
+func (p *Projection) Revision() (uint64, time.Time) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.revision, p.modified
+}
+
+// Lifted returns the time the last embargo has ended before now, it is zero if none has.
+// The feeds change when an embargo ends even though the articles don't.
+func (p *Projection) Lifted(now time.Time) time.Time {
+	p.RLock()
+	defer p.RUnlock()
+
+	var res time.Time
+	for _, a := range p.articles {
+		until, err := ptypes.Timestamp(a.EmbargoUntil)
+		if err == nil && !until.After(now) && until.After(res) {
+			res = until
+		}
+	}
+	return res
+}
+
+// Categories returns the sorted categories which have published articles which are not under embargo at now.
+func (p *Projection) Categories(now time.Time) []string {
+	p.RLock()
+	defer p.RUnlock()
+
+	seen := make(map[string]bool)
+	var res []string
+	for _, a := range p.articles {
+		if !seen[a.Category] && !articles.Embargoed(a, now) {
+			seen[a.Category] = true
+			res = append(res, a.Category)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (p *Projection) apply(ctx context.Context, e *pb.Event) error {
+	occurred, err := ptypes.Timestamp(e.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to convert date: %v", err)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	// the consumer is cancelled while the projection is being rebuilt
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	revision := p.revision
+	// the projection is built from the beginning of the log, so the first event identifies it
+	if p.position == 0 {
+		p.logID = e.Id
+	}
+
+	switch pl := e.Payload.(type) {
+	case *pb.Event_ArticleCreated:
+		p.put(pl.ArticleCreated.Article)
+	case *pb.Event_ArticleUpdated:
+		p.put(pl.ArticleUpdated.Article)
+	case *pb.Event_ArticleDrafted:
+		p.put(pl.ArticleDrafted.Article)
+	case *pb.Event_ArticlePublished:
+		p.put(pl.ArticlePublished.Article)
+	case *pb.Event_ArticleRetracted:
+		p.put(pl.ArticleRetracted.Article)
+	case *pb.Event_ArticleScheduled:
+		p.put(pl.ArticleScheduled.Article)
+	case *pb.Event_ArticleRetitled:
+		if a, ok := p.articles[e.AggregateId]; ok {
+			a = proto.Clone(a).(*pb.Article)
+			a.Title = pl.ArticleRetitled.Title
+			p.put(a)
+		}
+	case *pb.Event_ArticleRecategorised:
+		if a, ok := p.articles[e.AggregateId]; ok {
+			a = proto.Clone(a).(*pb.Article)
+			a.Category = pl.ArticleRecategorised.Category
+			p.put(a)
+		}
+	case *pb.Event_AuthorCreated:
+		p.rename(e.AggregateId, pl.AuthorCreated.Author.Name)
+		p.setEmail(e.AggregateId, pl.AuthorCreated.Author.Email)
+	case *pb.Event_AuthorUpdated:
+		p.setEmail(e.AggregateId, pl.AuthorUpdated.Author.Email)
+	case *pb.Event_AuthorRenamed:
+		p.rename(e.AggregateId, pl.AuthorRenamed.Name)
+	}
+	if p.revision != revision {
+		p.modified = occurred
+	}
+	p.position = e.Position
+	p.dirty = true
+	return nil
+}
+
+// put must be called while holding the write lock. Only the published articles are kept.
+// The articles are shared with the readers so they are replaced rather than modified.
+func (p *Projection) put(a *pb.Article) {
+	if a.GetStatus() != pb.ArticleStatus_PUBLISHED {
+		if _, ok := p.articles[a.GetId()]; ok {
+			delete(p.articles, a.GetId())
+			p.revision++
+		}
+		return
+	}
+	if name, ok := p.authors[a.AuthorId]; ok && name != a.AuthorName {
+		a = proto.Clone(a).(*pb.Article)
+		a.AuthorName = name
+	}
+	p.articles[a.Id] = a
+	p.revision++
+}
+
+// rename must be called while holding the write lock. It shows the new name of the author in their articles.
+func (p *Projection) rename(author uint32, name string) {
+	p.authors[author] = name
+	for _, a := range p.articles {
+		if a.AuthorId == author {
+			p.put(a)
+		}
+	}
+}
+
+// setEmail must be called while holding the write lock. The feeds change if the author has any articles in them.
+func (p *Projection) setEmail(author uint32, email string) {
+	if p.emails[author] == email {
+		return
+	}
+	if email == "" {
+		delete(p.emails, author)
+	} else {
+		p.emails[author] = email
+	}
+	for _, a := range p.articles {
+		if a.AuthorId == author {
+			p.revision++
+			return
+		}
+	}
+}
+
+// Email returns the email address of the author, it is empty if the author has none.
+func (p *Projection) Email(author uint32) string {
+	p.RLock()
+	defer p.RUnlock()
+	return p.emails[author]
+}
+
+// reset must be called while holding the write lock.
+func (p *Projection) reset() {
+	p.position = 0
+	p.logID = ""
+	p.articles = make(map[uint32]*pb.Article)
+	p.authors = make(map[uint32]string)
+	p.emails = make(map[uint32]string)
+	p.revision++
+	p.modified = time.Now()
+	p.dirty = true
+}
+
+func (p *Projection) checkpoints(ctx context.Context) {
+	t := time.NewTicker(checkpointInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			p.save()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// save writes the checkpoint if the state has changed since the last one.
+func (p *Projection) save() {
+	if p.path == "" {
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if !p.dirty {
+		return
+	}
+	data, err := json.Marshal(checkpoint{Position: p.position, LogID: p.logID, Articles: p.articles, Authors: p.authors, Emails: p.emails, Modified: p.modified})
+	if err != nil {
+		log.Printf("failed to save the feeds checkpoint: %v\n", err)
+		return
+	}
+	// write to a temporary file first so that a crash doesn't leave a truncated checkpoint
+	tmp := p.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		log.Printf("failed to save the feeds checkpoint: %v\n", err)
+		return
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		log.Printf("failed to save the feeds checkpoint: %v\n", err)
+		return
+	}
+	p.dirty = false
+}
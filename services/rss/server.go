@@ -1,6 +1,7 @@
 package rss
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"net/http"
@@ -11,56 +12,128 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/gorilla/feeds"
 
+	"github.com/pavelnikolov/eventsourcing-go/eventstream"
 	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
 )
 
 const port = "4002"
 
-// StartServer starts http server and exposes RSS feed endpoints
-func StartServer(c pb.ArticlesClient) {
-	http.Handle("/feed", rssHanlder(c, ""))
-	http.Handle("/feed/business", rssHanlder(c, "business"))
-	http.Handle("/feed/politics", rssHanlder(c, "politics"))
+// DefaultAdminAddress is where the admin endpoints are served by default, it is reachable from the local host only.
+const DefaultAdminAddress = "localhost:4012"
+
+// StartServer starts http server and exposes the feed endpoints served from the projection:
+// /feed for all categories and /feed/{category} for every category with published articles.
+// Every feed is available as RSS 2.0, Atom and JSON Feed 1.1, e.g. /feed, /feed.atom and /feed.json.
+// Without a suffix the format is negotiated by the Accept header. The feeds link to the articles on host.
+// The admin endpoints, i.e. /rebuild, are served on their own listener at admin unless it is empty.
+func StartServer(p *Projection, host, admin string) {
+	h := feedHandler(p, host)
+	for _, format := range formats {
+		http.Handle("/feed"+format.ext, h)
+	}
+	http.Handle("/feed/", h)
+	http.Handle("/feeds.opml", opmlHandler(p, host))
+
+	if admin != "" {
+		m := http.NewServeMux()
+		m.Handle("/rebuild", rebuildHandler(p))
+		log.Printf("Listening for admin connections on http://%s/rebuild\n", admin)
+		go func() { log.Fatal(http.ListenAndServe(admin, m)) }()
+	}
 
 	log.Printf("Listening for connections on http://localhost:%s/feed\n", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
 
-func rssHanlder(c pb.ArticlesClient, category string) http.HandlerFunc {
+// feedHandler serves the feeds from a cache which is dropped whenever the projection changes.
+// The clients which already have the current version of a feed get 304 Not Modified.
+func feedHandler(p *Projection, host string) http.HandlerFunc {
+	c := newCache(p)
 	return func(w http.ResponseWriter, r *http.Request) {
-		req := &pb.LatestArticlesRequest{
-			Category: category,
-			Count:    20,
-			Status:   pb.ArticleStatus_PUBLISHED,
+		if err := p.Err(); err != nil {
+			eventstream.Error(w, err)
+			log.Printf("failed to serve feed: %v\n", err)
+			return
 		}
-		res, err := c.LatestArticles(r.Context(), req)
-		if err != nil {
-			http.Error(w, "failed to query articles", http.StatusInternalServerError)
-			log.Printf("failed to fetch articles: %v\n", err)
+		now := time.Now()
+		f := formatOf(r.URL.Path, r.Header.Get("Accept"))
+		category, ok := categoryOf(p, strings.TrimSuffix(r.URL.Path, f.ext), now)
+		if !ok {
+			http.NotFound(w, r)
 			return
 		}
 
-		feed, err := generateFeed(res.Articles)
+		doc, err := c.get(category+f.ext, now, func() ([]byte, error) {
+			feed, err := generateFeed(host, category, p.Latest(category, 20, now), p.Email)
+			if err != nil {
+				return nil, err
+			}
+			var buf bytes.Buffer
+			if err := f.write(&buf, feed); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		})
 		if err != nil {
-			http.Error(w, "failed to generate RSS feed", http.StatusInternalServerError)
-			log.Printf("failed to generate RSS feed: %v\n", err)
+			http.Error(w, "failed to generate feed", http.StatusInternalServerError)
+			log.Printf("failed to generate feed: %v\n", err)
 			return
 		}
 
-		w.Header().Add("Content-Type", "application/rss+xml")
-		feed.WriteRss(w)
+		w.Header().Add("Content-Type", f.contentType)
+		w.Header().Add("Vary", "Accept")
+		doc.serve(w, r)
+	}
+}
+
+// categoryOf returns the category of the feed at urlPath, without the format suffix. The category is
+// empty for the feed of all categories. Only the categories with published articles have feeds.
+func categoryOf(p *Projection, urlPath string, now time.Time) (string, bool) {
+	if urlPath == "/feed" {
+		return "", true
+	}
+	for _, c := range p.Categories(now) {
+		if urlPath == feedPath(c) {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// feedPath returns the path of the feed of category, or of all categories if category is empty.
+func feedPath(category string) string {
+	if category == "" {
+		return "/feed"
 	}
+	return "/feed/" + urls.Slug(category)
 }
 
-func generateFeed(articles []*pb.Article) (*feeds.Feed, error) {
+// rebuildHandler discards the projection and replays the events from the beginning.
+func rebuildHandler(p *Projection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		p.Rebuild()
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// generateFeed returns the feed of category, or of all categories if category is empty.
+// The authors are listed with the addresses returned by email, if they have one.
+func generateFeed(host, category string, articles []*pb.Article, email func(author uint32) string) (*feed, error) {
 	now := time.Now()
-	feed := &feeds.Feed{
+	res := &feed{Feed: &feeds.Feed{
 		Title:       "Company Name Here",
-		Link:        &feeds.Link{Href: "https://example.com"},
+		Link:        &feeds.Link{Href: host},
 		Description: "When news breaks, we fix it!",
 		Author:      &feeds.Author{Name: "Company Name Here", Email: "contact@example.com"},
 		Created:     now,
-	}
+		Id:          guid("feed", category),
+	}}
 
 	for _, a := range articles {
 		created, err := ptypes.Timestamp(a.Created)
@@ -70,20 +143,45 @@ func generateFeed(articles []*pb.Article) (*feeds.Feed, error) {
 
 		item := &feeds.Item{
 			Title:       a.Title,
-			Link:        &feeds.Link{Href: fmt.Sprintf("http://example.com/%s/%s-%d", toURLPath(a.Category), toURLPath(a.Title), a.Id)},
-			Description: a.Body,
-			Author:      &feeds.Author{Name: a.AuthorName, Email: toURLPath(a.AuthorName) + "@example.com"},
+			Link:        &feeds.Link{Href: urls.Article(host, a)},
+			Description: summary(a.Body),
+			Content:     a.Body,
+			Id:          guid("article", fmt.Sprint(a.Id)),
+			Author:      &feeds.Author{Name: a.AuthorName, Email: email(a.AuthorId)},
 			Created:     created,
 		}
-		feed.Items = append(feed.Items, item)
+		if a.Modified != nil {
+			if item.Updated, err = ptypes.Timestamp(a.Modified); err != nil {
+				return nil, fmt.Errorf("failed to convert date: %v", err)
+			}
+		}
+		res.Items = append(res.Items, item)
+		res.categories = append(res.categories, a.Category)
 	}
 
-	return feed, nil
+	return res, nil
+}
+
+// guid returns a tag URI which identifies an entity for good, unlike its link which changes with the category.
+func guid(kind, id string) string {
+	if id == "" {
+		return fmt.Sprintf("tag:example.com,2018:%s", kind)
+	}
+	return fmt.Sprintf("tag:example.com,2018:%s:%s", kind, id)
 }
 
-func toURLPath(s string) string {
-	f := func(c rune) bool {
-		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+// summaryLength is the maximum number of characters of the summaries.
+const summaryLength = 200
+
+// summary shortens the text to summaryLength at a word boundary.
+func summary(s string) string {
+	r := []rune(s)
+	if len(r) <= summaryLength {
+		return s
+	}
+	r = r[:summaryLength]
+	if i := strings.LastIndexFunc(string(r), unicode.IsSpace); i > 0 {
+		return strings.TrimRightFunc(string(r)[:i], unicode.IsPunct) + "…"
 	}
-	return strings.ToLower(strings.Join(strings.FieldsFunc(s, f), "-"))
+	return string(r) + "…"
 }
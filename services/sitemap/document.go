@@ -0,0 +1,48 @@
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// document is a generated response which is served until the data it is generated from changes.
+type document struct {
+	content  []byte
+	etag     string
+	modified time.Time
+	// expires is when the document goes stale regardless of the data, it never does if zero
+	expires time.Time
+}
+
+func newDocument(content []byte, modified time.Time) *document {
+	sum := sha1.Sum(content)
+	return &document{content: content, etag: `"` + hex.EncodeToString(sum[:]) + `"`, modified: modified}
+}
+
+func (d *document) expired(now time.Time) bool {
+	return !d.expires.IsZero() && !now.Before(d.expires)
+}
+
+// gzip returns the compressed document.
+func (d *document) gzip() *document {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	// writing to a buffer never fails
+	w.Write(d.content)
+	w.Close()
+
+	res := newDocument(buf.Bytes(), d.modified)
+	res.expires = d.expires
+	return res
+}
+
+// serve responds with 304 Not Modified if the client has the current version of the document.
+func (d *document) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("ETag", d.etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, "", d.modified, bytes.NewReader(d.content))
+}
@@ -0,0 +1,358 @@
+package sitemap
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pavelnikolov/eventsourcing-go/eventstream"
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/urls"
+)
+
+// page is a published article in the sitemap
+type page struct {
+	id        uint32
+	title     string
+	slug      string
+	category  string
+	published time.Time
+	lastmod   time.Time
+	// embargoUntil keeps the page out of the sitemaps until it passes
+	embargoUntil time.Time
+}
+
+func (p page) loc(host string) string {
+	return urls.Article(host, &pb.Article{Id: p.id, Title: p.title, Slug: p.slug, Category: p.category})
+}
+
+// Projection is the read model of the sitemaps. It keeps the URL of every published
+// article up to date from the article events and regenerates the sitemaps when they change.
+type Projection struct {
+	// host is the host of the URLs of the articles
+	host string
+	// sitemapsHost is where the sitemaps are served from, the index links to them by absolute URLs
+	sitemapsHost string
+	pages        map[uint32]page
+	// position is the position of the last applied event
+	position uint64
+	// logID is the ID of the first event of the log the position refers to
+	logID string
+	// modified is when the pages last changed
+	modified time.Time
+	// docs are the generated sitemaps by name, they are dropped when the pages change
+	docs map[string]*document
+	// stream is the state of the event stream
+	stream eventstream.Status
+	sync.RWMutex
+}
+
+// NewProjection returns an empty projection of the articles on host, whose sitemaps are served from sitemapsHost.
+func NewProjection(host, sitemapsHost string) *Projection {
+	return &Projection{host: host, sitemapsHost: sitemapsHost, pages: make(map[uint32]page), docs: make(map[string]*document)}
+}
+
+// Run keeps the projection up to date with the events of the articles service until ctx is done.
+// It replays the events from the beginning and starts over if the event log has been replaced
+// in the meantime, e.g. because it has been wiped, or if the projection is ahead of it.
+func (p *Projection) Run(ctx context.Context, c pb.ArticlesClient) error {
+	for {
+		p.RLock()
+		req := &pb.SubscribeEventsRequest{Start: pb.SubscribeEventsRequest_AFTER, Position: p.position, LogId: p.logID}
+		p.RUnlock()
+
+		err := eventstream.Consume(ctx, c, req, p.apply, &p.stream)
+		switch status.Code(err) {
+		case codes.FailedPrecondition, codes.OutOfRange:
+		default:
+			return err
+		}
+		log.Printf("the sitemap doesn't match the event log, rebuilding: %v\n", err)
+		p.Lock()
+		p.pages, p.position, p.logID = make(map[uint32]page), 0, ""
+		p.invalidate(time.Now())
+		p.Unlock()
+	}
+}
+
+// Err returns the error of the event stream while the projection has nothing to serve, e.g. because the
+// articles service has been unreachable since the start. Otherwise the sitemaps are served even if they are behind.
+func (p *Projection) Err() error {
+	p.RLock()
+	defer p.RUnlock()
+
+	if p.position > 0 {
+		return nil
+	}
+	return p.stream.Err()
+}
+
+// document returns the sitemap with the given name, e.g. "sitemap.xml" or "sitemap.xml.gz".
+// The sitemaps are generated only after the pages have changed or the news have gone stale.
+func (p *Projection) document(name string, now time.Time) (*document, bool) {
+	p.RLock()
+	d, ok := p.docs[name]
+	p.RUnlock()
+	if ok && !d.expired(now) {
+		return d, true
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	return p.generate(name, now)
+}
+
+// generate must be called while holding the write lock. The sitemaps expire when
+// the next embargo ends, the end of the last one is a change of the sitemaps too.
+func (p *Projection) generate(name string, now time.Time) (*document, bool) {
+	prev, ok := p.docs[name]
+	if ok && !prev.expired(now) {
+		return prev, true
+	}
+
+	lifted, next := p.embargoes(now)
+	modified := p.modified
+	if lifted.After(modified) {
+		modified = lifted
+	}
+
+	var d *document
+	switch {
+	case strings.HasSuffix(name, ".gz"):
+		plain, ok := p.generate(strings.TrimSuffix(name, ".gz"), now)
+		if !ok {
+			return nil, false
+		}
+		d = plain.gzip()
+	case name == indexName:
+		d = newDocument(buildIndex(p.sitemapsHost, p.chunks(now), modified), modified)
+		d.expires = next
+	case name == newsName:
+		pages, expires := p.news(now)
+		// the news which have gone stale are a change too
+		if prev != nil && prev.expires.After(modified) {
+			modified = prev.expires
+		}
+		d = newDocument(buildNewsSitemap(p.host, pages).XMLContent(), modified)
+		d.expires = earliest(expires, next)
+	default:
+		for _, c := range p.chunks(now) {
+			if c.name == name {
+				d = newDocument(buildSitemap(p.host, c.pages).XMLContent(), c.lastmod)
+				d.expires = next
+				break
+			}
+		}
+		if d == nil {
+			return nil, false
+		}
+	}
+	p.docs[name] = d
+	return d, true
+}
+
+// embargoes must be called while holding the lock. It returns the time the last embargo
+// has ended before now and the time the next one ends, they are zero if there are none.
+func (p *Projection) embargoes(now time.Time) (lifted, next time.Time) {
+	for _, pg := range p.pages {
+		switch {
+		case pg.embargoUntil.After(now):
+			next = earliest(next, pg.embargoUntil)
+		case pg.embargoUntil.After(lifted):
+			lifted = pg.embargoUntil
+		}
+	}
+	return lifted, next
+}
+
+// earliest returns the earlier of the times, the zero time stands for never.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+func (p *Projection) apply(ctx context.Context, e *pb.Event) error {
+	occurred, err := ptypes.Timestamp(e.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to convert date: %v", err)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	// the projection is built from the beginning of the log, so the first event identifies it
+	if p.position == 0 {
+		p.logID = e.Id
+	}
+
+	switch pl := e.Payload.(type) {
+	case *pb.Event_ArticleCreated:
+		p.put(pl.ArticleCreated.Article, occurred)
+	case *pb.Event_ArticleUpdated:
+		p.put(pl.ArticleUpdated.Article, occurred)
+	case *pb.Event_ArticleDrafted:
+		p.put(pl.ArticleDrafted.Article, occurred)
+	case *pb.Event_ArticlePublished:
+		p.put(pl.ArticlePublished.Article, occurred)
+	case *pb.Event_ArticleRetracted:
+		p.put(pl.ArticleRetracted.Article, occurred)
+	case *pb.Event_ArticleScheduled:
+		p.put(pl.ArticleScheduled.Article, occurred)
+	case *pb.Event_ArticleRetitled:
+		if pg, ok := p.pages[e.AggregateId]; ok {
+			pg.title, pg.lastmod = pl.ArticleRetitled.Title, occurred
+			p.pages[e.AggregateId] = pg
+			p.invalidate(occurred)
+		}
+	case *pb.Event_ArticleRecategorised:
+		if pg, ok := p.pages[e.AggregateId]; ok {
+			pg.category, pg.lastmod = pl.ArticleRecategorised.Category, occurred
+			p.pages[e.AggregateId] = pg
+			p.invalidate(occurred)
+		}
+	}
+	p.position = e.Position
+	return nil
+}
+
+// put must be called while holding the write lock. The articles which are not
+// published are removed from the sitemap, the changes of drafts are ignored.
+func (p *Projection) put(a *pb.Article, lastmod time.Time) {
+	if a.Status != pb.ArticleStatus_PUBLISHED {
+		if _, ok := p.pages[a.Id]; ok {
+			delete(p.pages, a.Id)
+			p.invalidate(lastmod)
+		}
+		return
+	}
+
+	published, err := ptypes.Timestamp(a.Published)
+	if err != nil {
+		// the article has been published by this very event
+		published = lastmod
+	}
+	pg := page{id: a.Id, title: a.Title, slug: a.Slug, category: a.Category, published: published, lastmod: lastmod}
+	if a.EmbargoUntil != nil {
+		if pg.embargoUntil, err = ptypes.Timestamp(a.EmbargoUntil); err != nil {
+			log.Printf("invalid embargo of article %d: %v\n", a.Id, err)
+		}
+	}
+	p.pages[a.Id] = pg
+	p.invalidate(lastmod)
+}
+
+// invalidate must be called while holding the write lock. It discards the generated sitemaps.
+func (p *Projection) invalidate(modified time.Time) {
+	if modified.After(p.modified) {
+		p.modified = modified
+	}
+	p.docs = make(map[string]*document)
+}
+
+// sortedPages must be called while holding the lock.
+func (p *Projection) sortedPages() []page {
+	res := make([]page, 0, len(p.pages))
+	for _, pg := range p.pages {
+		res = append(res, pg)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].id < res[j].id })
+	return res
+}
+
+// visiblePages must be called while holding the lock. It leaves out the pages under embargo
+// at now, the others are published and modified no earlier than their embargo has ended.
+func (p *Projection) visiblePages(now time.Time) []page {
+	var res []page
+	for _, pg := range p.sortedPages() {
+		if pg.embargoUntil.After(now) {
+			continue
+		}
+		if pg.embargoUntil.After(pg.published) {
+			pg.published = pg.embargoUntil
+		}
+		if pg.embargoUntil.After(pg.lastmod) {
+			pg.lastmod = pg.embargoUntil
+		}
+		res = append(res, pg)
+	}
+	return res
+}
+
+// chunk is a sitemap listed in the index.
+type chunk struct {
+	name    string
+	pages   []page
+	lastmod time.Time
+}
+
+// chunks must be called while holding the lock. It splits the pages visible at now
+// by the month of publication into sitemaps of at most maxURLs pages.
+func (p *Projection) chunks(now time.Time) []chunk {
+	months := make(map[string][]page)
+	for _, pg := range p.visiblePages(now) {
+		m := pg.published.UTC().Format("2006-01")
+		months[m] = append(months[m], pg)
+	}
+	var keys []string
+	for m := range months {
+		keys = append(keys, m)
+	}
+	sort.Strings(keys)
+
+	var res []chunk
+	for _, m := range keys {
+		pages := months[m]
+		for i := 0; i < len(pages); i += maxURLs {
+			c := chunk{name: fmt.Sprintf("sitemaps/articles-%s.xml", m)}
+			if i > 0 {
+				c.name = fmt.Sprintf("sitemaps/articles-%s-%d.xml", m, i/maxURLs+1)
+			}
+			c.pages = pages[i:]
+			if len(c.pages) > maxURLs {
+				c.pages = c.pages[:maxURLs]
+			}
+			for _, pg := range c.pages {
+				if pg.lastmod.After(c.lastmod) {
+					c.lastmod = pg.lastmod
+				}
+			}
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// news must be called while holding the lock. It returns the most recently published pages
+// which belong to the news sitemap at now and the time the oldest of them goes stale.
+func (p *Projection) news(now time.Time) ([]page, time.Time) {
+	var res []page
+	for _, pg := range p.visiblePages(now) {
+		if now.Sub(pg.published) < newsAge {
+			res = append(res, pg)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].published.Equal(res[j].published) {
+			return res[i].published.After(res[j].published)
+		}
+		return res[i].id > res[j].id
+	})
+	if len(res) > maxNewsURLs {
+		res = res[:maxNewsURLs]
+	}
+
+	var expires time.Time
+	if len(res) > 0 {
+		expires = res[len(res)-1].published.Add(newsAge)
+	}
+	return res, expires
+}
@@ -1,79 +1,155 @@
 package sitemap
 
 import (
+	"encoding/xml"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
-	"unicode"
+	"time"
 
-	"github.com/golang/protobuf/ptypes"
 	"github.com/ikeikeikeike/go-sitemap-generator/stm"
 
-	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+	"github.com/pavelnikolov/eventsourcing-go/eventstream"
 )
 
 const port = "4003"
 
-// StartServer starts http server and exposes RSS feed endpoints
-func StartServer(c pb.ArticlesClient) {
-	http.Handle("/sitemap", sitemapHanlder(c))
+// DefaultSitemapsHost is where the sitemaps are served from locally.
+const DefaultSitemapsHost = "http://localhost:" + port
 
-	log.Printf("Listening for connections on http://localhost:%s/sitemap\n", port)
+const (
+	indexName = "sitemap.xml"
+	newsName  = "news-sitemap.xml"
+
+	// maxURLs is the maximum number of URLs in a sitemap
+	maxURLs = 50000
+	// maxNewsURLs is the maximum number of URLs in a Google News sitemap
+	maxNewsURLs = 1000
+	// newsAge is how long the articles stay in the Google News sitemap after they are published
+	newsAge = 48 * time.Hour
+
+	publicationName     = "Company Name Here"
+	publicationLanguage = "en"
+)
+
+// StartServer starts http server and exposes the sitemaps served from the projection: the sitemap index
+// at /sitemap.xml, the monthly sitemaps of the articles it links to and the Google News sitemap at
+// /news-sitemap.xml. Every sitemap is available gzip-compressed too, e.g. /sitemap.xml.gz.
+func StartServer(p *Projection) {
+	h := sitemapHanlder(p)
+	http.Handle("/sitemap", h)
+	http.Handle("/"+indexName, h)
+	http.Handle("/"+indexName+".gz", h)
+	http.Handle("/"+newsName, h)
+	http.Handle("/"+newsName+".gz", h)
+	http.Handle("/sitemaps/", h)
+	http.Handle("/robots.txt", robotsHandler(p.sitemapsHost))
+
+	log.Printf("Listening for connections on http://localhost:%s/%s\n", port, indexName)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
 
-func sitemapHanlder(c pb.ArticlesClient) http.HandlerFunc {
+func sitemapHanlder(p *Projection) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		req := &pb.LatestArticlesRequest{
-			Count:  20,
-			Status: pb.ArticleStatus_PUBLISHED,
+		if err := p.Err(); err != nil {
+			eventstream.Error(w, err)
+			log.Printf("failed to serve sitemap: %v\n", err)
+			return
 		}
-		res, err := c.LatestArticles(r.Context(), req)
-		if err != nil {
-			http.Error(w, "failed to query articles", http.StatusInternalServerError)
-			log.Printf("failed to fetch articles: %v\n", err)
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		if name == "sitemap" {
+			name = indexName
+		}
+		d, ok := p.document(name, time.Now())
+		if !ok {
+			http.NotFound(w, r)
 			return
 		}
 
-		sm := buildSitemap(res.Articles)
+		if strings.HasSuffix(name, ".gz") {
+			w.Header().Add("Content-Type", "application/x-gzip")
+		} else {
+			w.Header().Add("Content-Type", "application/xml")
+		}
+		d.serve(w, r)
+	}
+}
 
-		w.Header().Add("Content-Type", "application/xml")
-		w.Write(sm.XMLContent())
+// robotsHandler points the crawlers to the sitemap index.
+func robotsHandler(sitemapsHost string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintf(w, "User-agent: *\nAllow: /\n\nSitemap: %s/%s\n", sitemapsHost, indexName)
 	}
 }
 
-func buildSitemap(articles []*pb.Article) *stm.Sitemap {
+func buildSitemap(host string, pages []page) *stm.Sitemap {
 	sm := stm.NewSitemap()
-	sm.SetDefaultHost("http://example.com")
+	sm.SetDefaultHost(host)
 
 	sm.Create()
-	sm.Add(stm.URL{"loc": "/", "changefreq": "daily"})
-	sm.Add(stm.URL{"loc": "/about", "mobile": true})
-
-	var urls []stm.URL
-	// generate a very naive and useless sitemap
-	for _, a := range articles {
-		urls = append(urls, stm.URL{
-			"loc":              fmt.Sprintf("http://example.com/%s/%s-%d", toURLPath(a.Category), toURLPath(a.Title), a.Id),
-			"title":            a.Title,
-			"keywords":         strings.Split(a.Title, " "),
-			"publication_date": ptypes.TimestampString(a.Created),
-			"access":           "Subscription",
-			"genres":           a.Category,
-		})
+	for _, pg := range pages {
+		sm.Add(stm.URL{"loc": pg.loc(host), "lastmod": pg.lastmod})
 	}
 
-	sm.Add(stm.URL{"loc": "/news", "changefreq": "hourly", "news": urls})
-
 	// Note: Do not call `sm.Finalize()` because it flushes
 	// the underlying datastructure from memory to disk.
 	return sm
 }
 
-func toURLPath(s string) string {
-	f := func(c rune) bool {
-		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+// buildNewsSitemap lists the pages in the Google News sitemap format.
+func buildNewsSitemap(host string, pages []page) *stm.Sitemap {
+	sm := stm.NewSitemap()
+	sm.SetDefaultHost(host)
+
+	sm.Create()
+	for _, pg := range pages {
+		sm.Add(stm.URL{
+			"loc": pg.loc(host),
+			"news": stm.URL{
+				"publication": stm.URL{
+					"name":     publicationName,
+					"language": publicationLanguage,
+				},
+				"title":            pg.title,
+				"publication_date": pg.published,
+			},
+		})
+	}
+	return sm
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	Lastmod string `xml:"lastmod,omitempty"`
+}
+
+// buildIndex lists the sitemaps of the articles and the Google News sitemap, which was last modified at news.
+// The sitemaps are linked on sitemapsHost.
+func buildIndex(sitemapsHost string, chunks []chunk, news time.Time) []byte {
+	index := sitemapIndex{}
+	for _, c := range chunks {
+		index.Sitemaps = append(index.Sitemaps, sitemapEntry{Loc: sitemapsHost + "/" + c.name, Lastmod: lastmod(c.lastmod)})
+	}
+	index.Sitemaps = append(index.Sitemaps, sitemapEntry{Loc: sitemapsHost + "/" + newsName, Lastmod: lastmod(news)})
+
+	data, err := xml.MarshalIndent(index, "", "  ")
+	if err != nil {
+		// the index consists of strings only
+		panic(err)
+	}
+	return append([]byte(xml.Header), data...)
+}
+
+func lastmod(t time.Time) string {
+	if t.IsZero() {
+		return ""
 	}
-	return strings.ToLower(strings.Join(strings.FieldsFunc(s, f), "-"))
+	return t.UTC().Format(time.RFC3339)
 }
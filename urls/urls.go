@@ -0,0 +1,92 @@
+// Package urls builds the canonical URLs of the articles.
+package urls
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	pb "github.com/pavelnikolov/eventsourcing-go/publishing"
+)
+
+// DefaultHost is the host of the canonical URLs unless configured otherwise.
+const DefaultHost = "http://example.com"
+
+// Article returns the canonical URL of the article on host, e.g. "http://example.com/business/the-title-42".
+func Article(host string, a *pb.Article) string {
+	return strings.TrimSuffix(host, "/") + Path(a)
+}
+
+// Path returns the canonical path of the article, e.g. "/business/the-title-42".
+// The articles which have no slug stored get one from their current title.
+func Path(a *pb.Article) string {
+	slug := a.Slug
+	if slug == "" {
+		slug = Slug(a.Title)
+	}
+	if slug == "" {
+		return fmt.Sprintf("/%s/%d", Slug(a.Category), a.Id)
+	}
+	return fmt.Sprintf("/%s/%s-%d", Slug(a.Category), slug, a.Id)
+}
+
+// Category returns the URL of the page of the category on host.
+func Category(host, category string) string {
+	return strings.TrimSuffix(host, "/") + "/" + Slug(category)
+}
+
+// ID returns the ID of the article at path, or 0 if path is not the path of an article.
+// Any path ending with the ID is accepted regardless of the category and the slug, so
+// the paths of the articles which have been renamed since can be resolved too.
+func ID(path string) uint32 {
+	path = strings.TrimSuffix(path, "/")
+	i := strings.LastIndexAny(path, "-/")
+	if i == -1 {
+		return 0
+	}
+	id, err := strconv.ParseUint(path[i+1:], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(id)
+}
+
+// Slug converts s to lower case ASCII words separated by dashes, e.g. "Crème Brûlée!" becomes
+// "creme-brulee". The letters are transliterated to their closest ASCII form.
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	word := func(w string) {
+		if dash {
+			b.WriteByte('-')
+			dash = false
+		}
+		b.WriteString(w)
+	}
+	for _, r := range s {
+		r = unicode.ToLower(r)
+		if t, ok := transliterations[r]; ok {
+			word(t)
+			continue
+		}
+		// the decomposition separates the accents from the letters, e.g. "é" becomes "e" and "\u0301",
+		// so the accented letters of the table match too
+		for _, d := range norm.NFKD.String(string(r)) {
+			if t, ok := transliterations[d]; ok {
+				word(t)
+				continue
+			}
+			switch {
+			case unicode.Is(unicode.Mn, d):
+			case d < unicode.MaxASCII && (unicode.IsLetter(d) || unicode.IsDigit(d)):
+				word(string(unicode.ToLower(d)))
+			default:
+				dash = b.Len() > 0
+			}
+		}
+	}
+	return b.String()
+}
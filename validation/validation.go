@@ -0,0 +1,34 @@
+// Package validation reports the invalid fields of the gRPC requests as InvalidArgument errors with BadRequest details.
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Violation returns the violation of field, described by err.
+func Violation(field string, err error) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: err.Error()}
+}
+
+// InvalidInput returns an InvalidArgument error carrying the violations as BadRequest details.
+func InvalidInput(violations []*errdetails.BadRequest_FieldViolation) error {
+	var msgs []string
+	for _, v := range violations {
+		msgs = append(msgs, v.Description)
+	}
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid input: %s", strings.Join(msgs, ", ")))
+	if ds, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = ds
+	}
+	return st.Err()
+}
+
+// InvalidField returns an InvalidArgument error of a single invalid field of the request.
+func InvalidField(field, description string) error {
+	return InvalidInput([]*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}})
+}